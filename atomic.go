@@ -0,0 +1,146 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// AtomicSkipList is a list whose lookups take no lock. Forward pointers are
+// atomic and writers, serialized by a mutex, publish a new node bottom-up
+// only after its own pointers are set, so a reader always sees a well formed
+// chain. A removed node keeps its forward pointers, which lets a reader
+// standing on it carry on past it. In exchange the list has no back pointers
+// or spans, so it offers only Set, Load, Remove and Length.
+type AtomicSkipList struct {
+	maxLevel int
+	head     *atomicNode
+	length   atomic.Int64
+	rand     *rand.Rand
+	mutex    sync.Mutex
+}
+
+type atomicNode struct {
+	key   string
+	value atomic.Pointer[[]byte]
+	next  []atomic.Pointer[atomicNode]
+}
+
+func NewAtomic(maxLevel int) *AtomicSkipList {
+	maxLevel = max(maxLevel, minLevel)
+	return &AtomicSkipList{
+		maxLevel: maxLevel,
+		head:     &atomicNode{next: make([]atomic.Pointer[atomicNode], maxLevel)},
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (list *AtomicSkipList) Length() int {
+	return int(list.length.Load())
+}
+
+// Load returns a copy of the value stored under key without taking a lock.
+func (list *AtomicSkipList) Load(key string) ([]byte, bool) {
+	node := list.find(key, nil)
+	if node == nil {
+		return nil, false
+	}
+	return copyBytes(*node.value.Load()), true
+}
+
+func (list *AtomicSkipList) Set(key string, value []byte) {
+	value = copyBytes(value)
+
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	history := make([]*atomicNode, list.maxLevel)
+	if node := list.find(key, history); node != nil {
+		node.value.Store(&value)
+		return
+	}
+
+	level := list.randomLevel()
+	node := &atomicNode{key: key, next: make([]atomic.Pointer[atomicNode], level)}
+	node.value.Store(&value)
+	for i := 0; i < level; i++ {
+		node.next[i].Store(history[i].next[i].Load())
+	}
+
+	// linking bottom-up means a node reachable on a level is reachable below
+	for i := 0; i < level; i++ {
+		history[i].next[i].Store(node)
+	}
+	list.length.Add(1)
+}
+
+func (list *AtomicSkipList) Remove(key string) bool {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	history := make([]*atomicNode, list.maxLevel)
+	node := list.find(key, history)
+	if node == nil {
+		return false
+	}
+
+	for i := len(node.next) - 1; i >= 0; i-- {
+		history[i].next[i].Store(node.next[i].Load())
+	}
+	list.length.Add(-1)
+	return true
+}
+
+func (list *AtomicSkipList) find(key string, history []*atomicNode) *atomicNode {
+	current := list.head
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		for next := current.next[i].Load(); next != nil && next.key < key; next = current.next[i].Load() {
+			current = next
+		}
+
+		if history != nil {
+			history[i] = current
+		}
+	}
+
+	node := current.next[0].Load()
+	if node == nil || node.key != key {
+		return nil
+	}
+	return node
+}
+
+// randomLevel is only called with the mutex held, since rand.Rand is not
+// safe for concurrent use.
+func (list *AtomicSkipList) randomLevel() int {
+	level := 1
+	for level < list.maxLevel && list.rand.Int31() > defaultPromote {
+		level++
+	}
+	return level
+}
@@ -0,0 +1,179 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAtomicSkipList(t *testing.T) {
+	list := NewAtomic(0)
+	_, ok := list.Load("a")
+	assert.False(t, ok)
+	assert.False(t, list.Remove("a"))
+
+	for _, i := range rand.Perm(100) {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+	assert.Equal(t, list.Length(), 100)
+
+	list.Set("42", []byte("changed"))
+	value, ok := list.Load("42")
+	assert.True(t, ok)
+	assert.Equal(t, value, []byte("changed"))
+	assert.Equal(t, list.Length(), 100)
+
+	value[0] = 'C'
+	value, _ = list.Load("42")
+	assert.Equal(t, value, []byte("changed"))
+
+	assert.True(t, list.Remove("42"))
+	assert.False(t, list.Remove("42"))
+	_, ok = list.Load("42")
+	assert.False(t, ok)
+	assert.Equal(t, list.Length(), 99)
+
+	list.Set("empty", nil)
+	value, ok = list.Load("empty")
+	assert.True(t, ok)
+	assert.Equal(t, value, []byte{})
+}
+
+func TestAtomicSkipListOrder(t *testing.T) {
+	list := NewAtomic(8)
+	keys := []string{}
+	for _, i := range rand.Perm(500) {
+		key := strconv.Itoa(i)
+		list.Set(key, nil)
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for i := 0; i < list.maxLevel; i++ {
+		var previous *atomicNode
+		for node := list.head.next[i].Load(); node != nil; node = node.next[i].Load() {
+			if previous != nil {
+				assert.Less(t, previous.key, node.key)
+			}
+			previous = node
+		}
+	}
+
+	var ordered []string
+	for node := list.head.next[0].Load(); node != nil; node = node.next[0].Load() {
+		ordered = append(ordered, node.key)
+	}
+	assert.Equal(t, ordered, keys)
+}
+
+func TestAtomicSkipListConcurrent(t *testing.T) {
+	list := NewAtomic(10)
+	for i := 0; i < 500; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	const readers, writers = 6, 2
+
+	wg := &sync.WaitGroup{}
+	wg.Add(readers + writers)
+	for w := 0; w < writers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 3000; i++ {
+				key := strconv.Itoa(rand.Intn(1000))
+				if i%2 == 0 {
+					list.Set(key, []byte(key))
+				} else {
+					list.Remove(key)
+				}
+			}
+		}()
+	}
+
+	for r := 0; r < readers; r++ {
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 3000; i++ {
+				key := strconv.Itoa(rand.Intn(1000))
+				if value, ok := list.Load(key); ok {
+					assert.Equal(t, string(value), key)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	count := 0
+	for node := list.head.next[0].Load(); node != nil; node = node.next[0].Load() {
+		count++
+	}
+	assert.Equal(t, count, list.Length())
+}
+
+type loadSetter interface {
+	Set(key string, value []byte)
+	Load(key string) ([]byte, bool)
+}
+
+// BenchmarkLoadMixed and BenchmarkLoadMixedAtomic run the same workload of
+// 95% reads and 5% writes against the locked and the lock free list.
+func BenchmarkLoadMixed(b *testing.B) {
+	benchmarkLoadMixed(b, New(15))
+}
+
+func BenchmarkLoadMixedAtomic(b *testing.B) {
+	benchmarkLoadMixed(b, NewAtomic(15))
+}
+
+func benchmarkLoadMixed(b *testing.B, list loadSetter) {
+	b.ReportAllocs()
+	keys := make([]string, 100000)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+		list.Set(keys[i], []byte(keys[i]))
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := rand.Intn(len(keys))
+		for pb.Next() {
+			key := keys[i%len(keys)]
+			if i%20 == 0 {
+				list.Set(key, []byte(key))
+			} else {
+				list.Load(key)
+			}
+			i++
+		}
+	})
+}
@@ -0,0 +1,49 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"strings"
+	"unsafe"
+)
+
+// SetBytes works like Set for a key held in a byte slice. The key is only
+// copied into a string when a new entry is inserted, so updating an existing
+// key does not allocate for the key.
+func (list *SkipList) SetBytes(key []byte, value []byte) {
+	list.core.SetWithKeyCopy(bytesView(key), value, strings.Clone)
+}
+
+// GetBytes works like Get for a key held in a byte slice, without converting
+// it to a string.
+func (list *SkipList) GetBytes(key []byte) *SkipListItem {
+	return list.core.Get(bytesView(key))
+}
+
+// bytesView returns a string sharing the memory of data. It is only used for
+// lookups and must never be stored in the list.
+func bytesView(data []byte) string {
+	return unsafe.String(unsafe.SliceData(data), len(data))
+}
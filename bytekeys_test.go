@@ -0,0 +1,106 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetBytesAndGetBytes(t *testing.T) {
+	list := New(10)
+
+	key := []byte("key")
+	list.SetBytes(key, []byte("value"))
+	key[0] = 'K'
+
+	assert.Equal(t, list.Keys(), []string{"key"})
+	assert.Nil(t, list.GetBytes(key))
+	assert.Equal(t, list.GetBytes([]byte("key")).Value(), []byte("value"))
+	assert.Equal(t, list.Get("key").Value(), []byte("value"))
+
+	list.SetBytes([]byte("key"), []byte("changed"))
+	assert.Equal(t, list.Length(), 1)
+	assert.Equal(t, list.Get("key").Value(), []byte("changed"))
+
+	list.SetBytes(nil, []byte("empty"))
+	assert.Equal(t, list.GetBytes([]byte{}).Value(), []byte("empty"))
+	assert.Equal(t, list.Keys(), []string{"", "key"})
+	checkRanks(t, list)
+}
+
+func benchmarkByteKeys() (*SkipList, [][]byte) {
+	list := New(15)
+	keys := make([][]byte, 10000)
+	for i := range keys {
+		keys[i] = []byte(fmt.Sprintf("session:%032d", i))
+		list.Set(string(keys[i]), keys[i])
+	}
+	return list, keys
+}
+
+func BenchmarkGetStringFromBytes(b *testing.B) {
+	b.ReportAllocs()
+	list, keys := benchmarkByteKeys()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		list.Get(string(keys[i%len(keys)]))
+	}
+}
+
+func BenchmarkGetBytes(b *testing.B) {
+	b.ReportAllocs()
+	list, keys := benchmarkByteKeys()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		list.GetBytes(keys[i%len(keys)])
+	}
+}
+
+func BenchmarkSetStringFromBytes(b *testing.B) {
+	b.ReportAllocs()
+	list, keys := benchmarkByteKeys()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		key := keys[i%len(keys)]
+		list.Set(string(key), key)
+	}
+}
+
+func BenchmarkSetBytes(b *testing.B) {
+	b.ReportAllocs()
+	list, keys := benchmarkByteKeys()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		key := keys[i%len(keys)]
+		list.SetBytes(key, key)
+	}
+}
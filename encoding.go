@@ -0,0 +1,256 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ISSuh/skiplist/generic"
+)
+
+const binaryVersion uint8 = 1
+
+// maxBinaryLevel bounds the max level read from a stream, so a corrupted
+// header cannot make ReadFrom allocate huge end nodes.
+const maxBinaryLevel = 64
+
+// decodeMaxLevel is the max level UnmarshalJSON gives a zero SkipList, since
+// the JSON form does not record one.
+const decodeMaxLevel = 16
+
+var ErrNotInitialized = errors.New("skiplist: list is not initialized, create it with New")
+
+type binaryHeader struct {
+	Version  uint8
+	MaxLevel uint32
+	Length   uint64
+}
+
+type jsonItem struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+func (list *SkipList) MarshalJSON() ([]byte, error) {
+	items := make([]jsonItem, 0)
+	list.ForEach(func(key string, value []byte) bool {
+		items = append(items, jsonItem{Key: key, Value: value})
+		return true
+	})
+	return json.Marshal(items)
+}
+
+// UnmarshalJSON replaces the contents of the list with the decoded entries.
+// A zero SkipList, which is what encoding/json decodes a pointer field into,
+// is first set up like New(16).
+func (list *SkipList) UnmarshalJSON(data []byte) error {
+	var items []jsonItem
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+
+	list.initZero(decodeMaxLevel)
+	list.Clear()
+	for _, item := range items {
+		list.Set(item.Key, item.Value)
+	}
+	return nil
+}
+
+func (list *SkipList) WriteTo(w io.Writer) (int64, error) {
+	writer := &countingWriter{writer: w}
+
+	var err error
+	list.WithReadLock(func(view ReadView) {
+		header := binaryHeader{
+			Version:  binaryVersion,
+			MaxLevel: uint32(view.MaxLevel()),
+			Length:   uint64(view.Length()),
+		}
+
+		if err = binary.Write(writer, binary.BigEndian, header); err != nil {
+			return
+		}
+
+		view.ForEach(func(key string, value []byte) bool {
+			if err = writeBytes(writer, []byte(key)); err != nil {
+				return false
+			}
+
+			err = writeBytes(writer, value)
+			return err == nil
+		})
+	})
+	return writer.count, err
+}
+
+func (list *SkipList) ReadFrom(r io.Reader) (int64, error) {
+	if list.core == nil {
+		return 0, ErrNotInitialized
+	}
+
+	reader := &countingReader{reader: r}
+
+	var header binaryHeader
+	if err := binary.Read(reader, binary.BigEndian, &header); err != nil {
+		return reader.count, unexpectedEOF(err)
+	}
+
+	if header.Version != binaryVersion {
+		return reader.count, fmt.Errorf("skiplist: unsupported binary format version %d", header.Version)
+	}
+
+	if header.MaxLevel > maxBinaryLevel {
+		return reader.count, fmt.Errorf("skiplist: max level %d exceeds %d", header.MaxLevel, maxBinaryLevel)
+	}
+
+	items := make([]SkipListItem, 0)
+	for i := uint64(0); i < header.Length; i++ {
+		key, err := readBytes(reader)
+		if err != nil {
+			return reader.count, err
+		}
+
+		value, err := readBytes(reader)
+		if err != nil {
+			return reader.count, err
+		}
+
+		items = append(items, generic.NewItem(string(key), value))
+	}
+
+	list.core.Reset(int(header.MaxLevel), items)
+	return reader.count, nil
+}
+
+// GobEncode encodes the list in the binary format of WriteTo, which keeps the
+// max level and the entries in key order.
+func (list *SkipList) GobEncode() ([]byte, error) {
+	var buffer bytes.Buffer
+	if _, err := list.WriteTo(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// GobDecode replaces the contents of the list with the encoded entries. A
+// zero SkipList, which is what gob decodes a pointer field into, is accepted
+// and takes the max level recorded in the data.
+func (list *SkipList) GobDecode(data []byte) error {
+	list.initZero(minLevel)
+	_, err := list.ReadFrom(bytes.NewReader(data))
+	return err
+}
+
+// initZero sets up a zero SkipList like New(maxLevel) and leaves a list made
+// by a constructor untouched.
+func (list *SkipList) initZero(maxLevel int) {
+	if list.core != nil {
+		return
+	}
+
+	*list = *New(maxLevel)
+}
+
+// WriteValues writes every value in key order to w, each followed by sep. It
+// returns the number of bytes written and stops at the first write error.
+func (list *SkipList) WriteValues(w io.Writer, sep []byte) (int, error) {
+	written := 0
+
+	var err error
+	list.ForEach(func(key string, value []byte) bool {
+		var n int
+		n, err = w.Write(value)
+		written += n
+		if err != nil {
+			return false
+		}
+
+		n, err = w.Write(sep)
+		written += n
+		return err == nil
+	})
+	return written, err
+}
+
+type countingWriter struct {
+	writer io.Writer
+	count  int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.count += int64(n)
+	return n, err
+}
+
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
+
+func writeBytes(w io.Writer, data []byte) error {
+	if err := binary.Write(w, binary.BigEndian, uint32(len(data))); err != nil {
+		return err
+	}
+
+	_, err := w.Write(data)
+	return err
+}
+
+func readBytes(r io.Reader) ([]byte, error) {
+	var length uint32
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, int64(length)))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) != int(length) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return data, nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,264 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	list := New(10)
+	for i := 0; i < 1000; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(randomString(i%20)))
+	}
+
+	data, err := json.Marshal(list)
+	assert.Nil(t, err)
+
+	restored := New(10)
+	restored.Set("stale", []byte("stale"))
+
+	err = json.Unmarshal(data, restored)
+	assert.Nil(t, err)
+
+	assert.Equal(t, restored.Length(), list.Length())
+	assert.Equal(t, restored.Size(), list.Size())
+	assert.Equal(t, restored.Keys(), list.Keys())
+	assert.Equal(t, restored.Values(), list.Values())
+}
+
+func TestJSONEmpty(t *testing.T) {
+	data, err := json.Marshal(New(5))
+	assert.Nil(t, err)
+	assert.Equal(t, string(data), "[]")
+
+	restored := New(5)
+	assert.Nil(t, json.Unmarshal(data, restored))
+	assert.Equal(t, restored.Length(), 0)
+}
+
+func TestJSONInvalid(t *testing.T) {
+	list := New(5)
+	assert.NotNil(t, json.Unmarshal([]byte(`{"key": 1}`), list))
+
+}
+
+func TestJSONInWrapper(t *testing.T) {
+	type wrapper struct {
+		Name string    `json:"name"`
+		List *SkipList `json:"list"`
+	}
+
+	list := New(5)
+	for i := 0; i < 20; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	data, err := json.Marshal(wrapper{Name: "w", List: list})
+	assert.Nil(t, err)
+
+	var decoded wrapper
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, decoded.Name, "w")
+	assert.Equal(t, decoded.List.MaxLevel(), decodeMaxLevel)
+	assert.True(t, decoded.List.Equal(list))
+	checkRanks(t, decoded.List)
+
+	var zero SkipList
+	assert.Nil(t, json.Unmarshal([]byte(`[{"key":"a","value":"YQ=="}]`), &zero))
+	assert.Equal(t, zero.Get("a").Value(), []byte("a"))
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	list := New(10)
+	for i := 0; i < 1000; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(randomString(i%20)))
+	}
+
+	buffer := &bytes.Buffer{}
+	written, err := list.WriteTo(buffer)
+	assert.Nil(t, err)
+	assert.Equal(t, written, int64(buffer.Len()))
+
+	restored := New(3)
+	restored.Set("stale", []byte("stale"))
+
+	read, err := restored.ReadFrom(buffer)
+	assert.Nil(t, err)
+	assert.Equal(t, read, written)
+
+	assert.Equal(t, restored.MaxLevel(), 10)
+	assert.Equal(t, restored.Length(), list.Length())
+	assert.Equal(t, restored.Size(), list.Size())
+	assert.Equal(t, restored.Keys(), list.Keys())
+	assert.Equal(t, restored.Values(), list.Values())
+}
+
+func TestGobRoundTrip(t *testing.T) {
+	type message struct {
+		Name string
+		List *SkipList
+	}
+
+	list := New(8)
+	for i := 0; i < 100; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(randomString(i%20)))
+	}
+
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, gob.NewEncoder(buffer).Encode(message{Name: "list", List: list}))
+
+	var decoded message
+	assert.Nil(t, gob.NewDecoder(buffer).Decode(&decoded))
+	assert.Equal(t, decoded.Name, "list")
+	assert.Equal(t, decoded.List.MaxLevel(), 8)
+	assert.True(t, decoded.List.Equal(list))
+	assert.Equal(t, decoded.List.Size(), list.Size())
+	checkRanks(t, decoded.List)
+
+	decoded.List.Set("new", []byte("new"))
+	assert.Equal(t, decoded.List.Length(), 101)
+}
+
+func TestBinaryRoundTripMultiset(t *testing.T) {
+	list, _ := NewWithOptions(Options{MaxLevel: 5, Multiset: true})
+	for i, key := range []string{"a", "b", "a", "a"} {
+		list.Set(key, []byte(strconv.Itoa(i)))
+	}
+
+	buffer := &bytes.Buffer{}
+	_, err := list.WriteTo(buffer)
+	assert.Nil(t, err)
+
+	restored, _ := NewWithOptions(Options{MaxLevel: 5, Multiset: true})
+	_, err = restored.ReadFrom(bytes.NewReader(buffer.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, restored.Length(), 4)
+	assert.Equal(t, restored.Keys(), []string{"a", "a", "a", "b"})
+	assert.Equal(t, restored.Values(), list.Values())
+
+	data, err := list.GobEncode()
+	assert.Nil(t, err)
+	decoded, _ := NewWithOptions(Options{MaxLevel: 5, Multiset: true})
+	assert.Nil(t, decoded.GobDecode(data))
+	assert.Equal(t, decoded.Values(), list.Values())
+}
+
+func TestGobIntoExistingList(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, gob.NewEncoder(buffer).Encode(New(4)))
+
+	restored := New(2)
+	restored.Set("stale", []byte("stale"))
+	assert.Nil(t, gob.NewDecoder(buffer).Decode(restored))
+	assert.Equal(t, restored.Length(), 0)
+	assert.Equal(t, restored.MaxLevel(), 4)
+	assert.Nil(t, restored.Front())
+}
+
+func TestBinaryCorrupted(t *testing.T) {
+	list := New(5)
+	for i := 0; i < 10; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	buffer := &bytes.Buffer{}
+	_, err := list.WriteTo(buffer)
+	assert.Nil(t, err)
+	data := buffer.Bytes()
+
+	for _, length := range []int{0, 5, 13, 20, len(data) - 1} {
+		restored := New(5)
+		restored.Set("keep", []byte("keep"))
+
+		_, err := restored.ReadFrom(bytes.NewReader(data[:length]))
+		assert.Equal(t, err, io.ErrUnexpectedEOF)
+		assert.Equal(t, restored.Keys(), []string{"keep"})
+	}
+
+	corrupted := append([]byte{}, data...)
+	corrupted[0] = 0xff
+	_, err = New(5).ReadFrom(bytes.NewReader(corrupted))
+	assert.NotNil(t, err)
+
+	// the max level follows the one byte version in the header
+	corrupted = append([]byte{}, data...)
+	binary.BigEndian.PutUint32(corrupted[1:], 1<<28)
+	restored := New(5)
+	restored.Set("keep", []byte("keep"))
+	_, err = restored.ReadFrom(bytes.NewReader(corrupted))
+	assert.NotNil(t, err)
+	assert.Equal(t, restored.Keys(), []string{"keep"})
+	assert.Equal(t, restored.MaxLevel(), 5)
+}
+
+type failingWriter struct {
+	limit int
+}
+
+func (w *failingWriter) Write(data []byte) (int, error) {
+	if len(data) > w.limit {
+		n := w.limit
+		w.limit = 0
+		return n, io.ErrShortWrite
+	}
+	w.limit -= len(data)
+	return len(data), nil
+}
+
+func TestWriteValues(t *testing.T) {
+	list := New(5)
+	list.Set("b", []byte("two"))
+	list.Set("a", []byte("one"))
+	list.Set("c", []byte("three"))
+
+	buffer := &bytes.Buffer{}
+	n, err := list.WriteValues(buffer, []byte("\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, buffer.String(), "one\ntwo\nthree\n")
+	assert.Equal(t, n, buffer.Len())
+
+	buffer.Reset()
+	n, err = New(5).WriteValues(buffer, []byte(","))
+	assert.Nil(t, err)
+	assert.Equal(t, n, 0)
+
+	n, err = list.WriteValues(&failingWriter{limit: 6}, []byte("\n"))
+	assert.Equal(t, err, io.ErrShortWrite)
+	assert.Equal(t, n, 6)
+}
@@ -0,0 +1,76 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generic
+
+// OnRemove registers fn to be called once for every entry that leaves the
+// list, whether through Remove, a range delete, Clear, expiry or eviction.
+// Overwriting a value does not count as a removal. fn runs after the list's
+// lock is released, so it may call back into the list. Passing nil removes
+// the hook.
+func (list *SkipList[K, V]) OnRemove(fn func(key K, value V)) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	list.onRemove = fn
+}
+
+// retire queues node for the remove hook. The caller must hold the write lock.
+func (list *SkipList[K, V]) retire(node *SkipListNode[K, V]) {
+	if list.onRemove != nil {
+		list.retired = append(list.retired, node)
+	}
+}
+
+func (list *SkipList[K, V]) retireAll() {
+	if list.onRemove == nil {
+		return
+	}
+
+	for node := list.head.nextNode[0]; !node.isEndNode; node = node.nextNode[0] {
+		list.retired = append(list.retired, node)
+	}
+}
+
+// unlock returns the recycled nodes to the pool, releases the write lock and
+// then runs the remove hook for every node retired while it was held.
+func (list *SkipList[K, V]) unlock() {
+	list.flushRecycled()
+	onRemove, retired := list.onRemove, list.takeRetired()
+	list.mutex.Unlock()
+
+	notifyRemoved(onRemove, retired)
+}
+
+func (list *SkipList[K, V]) takeRetired() []*SkipListNode[K, V] {
+	retired := list.retired
+	list.retired = nil
+	return retired
+}
+
+func notifyRemoved[K any, V any](onRemove func(key K, value V), retired []*SkipListNode[K, V]) {
+	for _, node := range retired {
+		onRemove(node.item.key, node.item.value)
+	}
+}
@@ -0,0 +1,269 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generic
+
+type Iterator[K any, V any] struct {
+	list *SkipList[K, V]
+	node *SkipListNode[K, V]
+}
+
+func (list *SkipList[K, V]) Iterator() *Iterator[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return &Iterator[K, V]{
+		list: list,
+		node: list.head.nextNode[0],
+	}
+}
+
+// SeekGE returns an iterator positioned at the first key greater than or
+// equal to key. It is invalid when every key is smaller.
+func (list *SkipList[K, V]) SeekGE(key K) *Iterator[K, V] {
+	it := &Iterator[K, V]{list: list}
+	it.Seek(key)
+	return it
+}
+
+func (it *Iterator[K, V]) Valid() bool {
+	return it.node != nil && !it.node.isEndNode
+}
+
+func (it *Iterator[K, V]) Next() {
+	if !it.Valid() {
+		return
+	}
+
+	it.list.mutex.RLock()
+	defer it.list.mutex.RUnlock()
+
+	it.node = it.node.nextNode[0]
+}
+
+// Prev moves the iterator to the previous key. Stepping back from the first
+// key leaves the iterator invalid.
+func (it *Iterator[K, V]) Prev() {
+	if !it.Valid() {
+		return
+	}
+
+	it.list.mutex.RLock()
+	defer it.list.mutex.RUnlock()
+
+	it.node = it.node.prevNode[0]
+}
+
+func (it *Iterator[K, V]) Seek(key K) {
+	it.list.mutex.RLock()
+	defer it.list.mutex.RUnlock()
+
+	it.node = it.list.findGreaterOrEqual(key, nil)
+}
+
+func (it *Iterator[K, V]) Key() K {
+	if !it.Valid() {
+		var zero K
+		return zero
+	}
+	return it.node.item.key
+}
+
+// Value returns a copy of the current value, read from the node the iterator
+// stands on without searching the list again.
+func (it *Iterator[K, V]) Value() V {
+	if !it.Valid() {
+		var zero V
+		return zero
+	}
+
+	it.list.mutex.RLock()
+	defer it.list.mutex.RUnlock()
+
+	return it.list.copyValue(it.node.item.value)
+}
+
+type ReverseIterator[K any, V any] struct {
+	list *SkipList[K, V]
+	node *SkipListNode[K, V]
+}
+
+func (list *SkipList[K, V]) ReverseIterator() *ReverseIterator[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return &ReverseIterator[K, V]{
+		list: list,
+		node: list.tail.prevNode[0],
+	}
+}
+
+// SeekLE returns a reverse iterator positioned at the last key less than or
+// equal to key. It is invalid when every key is larger.
+func (list *SkipList[K, V]) SeekLE(key K) *ReverseIterator[K, V] {
+	it := &ReverseIterator[K, V]{list: list}
+	it.SeekForPrev(key)
+	return it
+}
+
+func (it *ReverseIterator[K, V]) Valid() bool {
+	return it.node != nil && !it.node.isEndNode
+}
+
+func (it *ReverseIterator[K, V]) Next() {
+	if !it.Valid() {
+		return
+	}
+
+	it.list.mutex.RLock()
+	defer it.list.mutex.RUnlock()
+
+	it.node = it.node.prevNode[0]
+}
+
+func (it *ReverseIterator[K, V]) SeekForPrev(key K) {
+	it.list.mutex.RLock()
+	defer it.list.mutex.RUnlock()
+
+	it.node = it.list.findLessOrEqual(key)
+}
+
+func (it *ReverseIterator[K, V]) Key() K {
+	if !it.Valid() {
+		var zero K
+		return zero
+	}
+	return it.node.item.key
+}
+
+// Value returns a copy of the current value, read from the node the iterator
+// stands on without searching the list again.
+func (it *ReverseIterator[K, V]) Value() V {
+	if !it.Valid() {
+		var zero V
+		return zero
+	}
+
+	it.list.mutex.RLock()
+	defer it.list.mutex.RUnlock()
+
+	return it.list.copyValue(it.node.item.value)
+}
+
+// ChunkedIterator walks the list in key order while holding the read lock
+// only to load the next chunk of items. Between chunks it re-seeks past the
+// entries it already returned, so it gives a weakly consistent view: writes
+// made during the scan may or may not be observed, but keys are never
+// returned out of order. Keys are strictly increasing unless the list is a
+// multiset.
+type ChunkedIterator[K any, V any] struct {
+	list      *SkipList[K, V]
+	chunkSize int
+	items     []SkipListItem[K, V]
+	index     int
+	done      bool
+
+	// repeat is the number of returned entries holding the last loaded key
+	repeat int
+}
+
+func (list *SkipList[K, V]) ChunkedIterator(chunkSize int) *ChunkedIterator[K, V] {
+	it := &ChunkedIterator[K, V]{
+		list:      list,
+		chunkSize: max(chunkSize, 1),
+	}
+
+	it.load(nil, 0)
+	return it
+}
+
+func (it *ChunkedIterator[K, V]) Valid() bool {
+	return it.index < len(it.items)
+}
+
+func (it *ChunkedIterator[K, V]) Next() {
+	if !it.Valid() {
+		return
+	}
+
+	it.index++
+	if it.index == len(it.items) && !it.done {
+		last := it.items[len(it.items)-1].key
+		it.load(&last, it.repeat)
+	}
+}
+
+func (it *ChunkedIterator[K, V]) Key() K {
+	if !it.Valid() {
+		var zero K
+		return zero
+	}
+	return it.items[it.index].key
+}
+
+func (it *ChunkedIterator[K, V]) Value() V {
+	if !it.Valid() {
+		var zero V
+		return zero
+	}
+	return it.items[it.index].value
+}
+
+// load fills the next chunk, starting after the first skip entries holding
+// the key after. Skipping a count rather than every equal key keeps a run of
+// multiset duplicates that spans two chunks from being lost or repeated.
+func (it *ChunkedIterator[K, V]) load(after *K, skip int) {
+	list := it.list
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	node := list.head.nextNode[0]
+	if after != nil {
+		node = list.findGreaterOrEqual(*after, nil)
+		for i := 0; i < skip && !node.isEndNode && node.match(*after, list.compare); i++ {
+			node = node.nextNode[0]
+		}
+	}
+
+	it.items = it.items[:0]
+	it.index = 0
+	for ; !node.isEndNode && len(it.items) < it.chunkSize; node = node.nextNode[0] {
+		it.items = append(it.items, SkipListItem[K, V]{key: node.item.key, value: list.copyValue(node.item.value)})
+	}
+	it.done = node.isEndNode
+
+	if len(it.items) == 0 {
+		return
+	}
+
+	last := it.items[len(it.items)-1].key
+	run := 0
+	for i := len(it.items) - 1; i >= 0 && list.compare(it.items[i].key, last) == 0; i-- {
+		run++
+	}
+	if run == len(it.items) && after != nil && list.compare(*after, last) == 0 {
+		run += skip
+	}
+	it.repeat = run
+}
@@ -0,0 +1,127 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generic
+
+import "cmp"
+
+// NewBounded creates a list that holds at most maxEntries keys. Inserting past
+// the bound evicts the least recently used key, where Get, Load and Set count
+// as uses.
+func NewBounded[K cmp.Ordered, V any](maxLevel int, maxEntries int) *SkipList[K, V] {
+	list := New[K, V](maxLevel)
+	list.maxEntries = max(maxEntries, 1)
+	list.recency = &SkipListNode[K, V]{}
+	list.resetRecency()
+	return list
+}
+
+func (list *SkipList[K, V]) resetRecency() {
+	if list.recency == nil {
+		return
+	}
+
+	list.recency.lruPrev = list.recency
+	list.recency.lruNext = list.recency
+}
+
+// touch moves node to the most recently used position. It may be called with
+// only the read lock held, so the recency list has its own mutex.
+func (list *SkipList[K, V]) touch(node *SkipListNode[K, V]) {
+	if list.recency == nil {
+		return
+	}
+
+	list.lruMutex.Lock()
+	defer list.lruMutex.Unlock()
+
+	list.unlinkRecency(node)
+
+	node.lruPrev = list.recency
+	node.lruNext = list.recency.lruNext
+	list.recency.lruNext.lruPrev = node
+	list.recency.lruNext = node
+}
+
+func (list *SkipList[K, V]) forget(node *SkipListNode[K, V]) {
+	if list.recency == nil {
+		return
+	}
+
+	list.lruMutex.Lock()
+	defer list.lruMutex.Unlock()
+
+	list.unlinkRecency(node)
+}
+
+func (list *SkipList[K, V]) unlinkRecency(node *SkipListNode[K, V]) {
+	if node.lruNext == nil {
+		return
+	}
+
+	node.lruPrev.lruNext = node.lruNext
+	node.lruNext.lruPrev = node.lruPrev
+	node.lruPrev = nil
+	node.lruNext = nil
+}
+
+// SetSizeLimit bounds the total size of keys and values reported by Size.
+// Going over the limit evicts the least recently used key on a bounded list
+// and the smallest key otherwise. A limit of 0 removes the bound.
+func (list *SkipList[K, V]) SetSizeLimit(maxBytes uint64) {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	list.maxBytes = maxBytes
+	list.evictInternal()
+}
+
+func (list *SkipList[K, V]) SizeLimit() uint64 {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.maxBytes
+}
+
+// evicting reports whether writes may unlink other nodes.
+func (list *SkipList[K, V]) evicting() bool {
+	return list.maxEntries > 0 || list.maxBytes > 0
+}
+
+func (list *SkipList[K, V]) evictInternal() {
+	for list.length > 0 && list.overLimit() {
+		if list.recency != nil {
+			list.deleteNode(list.recency.lruPrev)
+		} else {
+			list.deleteNode(list.head.nextNode[0])
+		}
+	}
+}
+
+func (list *SkipList[K, V]) overLimit() bool {
+	if list.maxEntries > 0 && list.length > list.maxEntries {
+		return true
+	}
+	return list.maxBytes > 0 && list.size > list.maxBytes
+}
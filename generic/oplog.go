@@ -0,0 +1,56 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generic
+
+type OpKind uint8
+
+const (
+	OpSet OpKind = iota
+	OpRemove
+)
+
+// Op is one entry of an operation log. Value is ignored for OpRemove.
+type Op[K any, V any] struct {
+	Kind  OpKind
+	Key   K
+	Value V
+}
+
+// Apply applies ops in order under a single write lock.
+func (list *SkipList[K, V]) Apply(ops []Op[K, V]) {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	for _, op := range ops {
+		switch op.Kind {
+		case OpSet:
+			list.setInternal(op.Key, op.Value)
+		case OpRemove:
+			if node := list.findInternal(op.Key, nil); node != nil {
+				list.deleteNode(node)
+			}
+		}
+	}
+}
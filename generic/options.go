@@ -0,0 +1,136 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generic
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+)
+
+var ErrInvalidProbability = errors.New("skiplist: probability must be in (0, 1)")
+
+var ErrNoComparator = errors.New("skiplist: comparator must be set")
+
+type Options[K any, V any] struct {
+	// MaxLevel is the maximum height of a node. Values below 1 are raised to 1.
+	MaxLevel int
+
+	// Probability is the chance a node is promoted to the next level.
+	// Zero selects the default of 0.5.
+	Probability float64
+
+	// Comparator orders the keys. It must be set.
+	Comparator func(a, b K) int
+
+	// Source seeds the level generator. Nil selects a time based seed.
+	Source rand.Source
+
+	// Growable raises MaxLevel by one each time the length exceeds
+	// 2^MaxLevel, so the list keeps logarithmic lookups as it grows.
+	Growable bool
+
+	// Multiset makes Set always insert, so a key may be stored several times.
+	// Lookups by key find the oldest entry; use EqualRange to get them all.
+	Multiset bool
+
+	// LevelFunc picks the height of each new node in place of the random
+	// generator, which makes the shape of the list reproducible. Results
+	// outside [1, MaxLevel] are clamped into that range.
+	LevelFunc func() int
+
+	// CacheLastAccess remembers the node found by the last lookup so that
+	// repeated reads of a hot key skip the search.
+	CacheLastAccess bool
+
+	// PoolNodes reuses the nodes of removed entries for later inserts, which
+	// cuts allocations under insert and delete churn. Items, nodes and
+	// iterators obtained from the list must then not be used once their entry
+	// has been removed, because the node may already hold another entry.
+	PoolNodes bool
+
+	// MaxEntries bounds the list like NewBounded. Zero leaves it unbounded.
+	MaxEntries int
+
+	// Clock tells the time for TTLs. Nil selects time.Now.
+	Clock func() time.Time
+
+	// KeySize and ValueSize measure entries for Size, MaxKeyLen, MaxValueLen
+	// and SetSizeLimit. Nil measures every key or value as 0.
+	KeySize   func(key K) int
+	ValueSize func(value V) int
+
+	// CopyValue makes the copies that reads such as Load, Values and ForEach
+	// hand out. Nil hands out the stored values.
+	CopyValue func(value V) V
+
+	// StoreValue makes what the list keeps for a value passed to a write.
+	// Nil keeps the value as it is.
+	StoreValue func(value V) V
+}
+
+func NewWithOptions[K any, V any](options Options[K, V]) (*SkipList[K, V], error) {
+	// written so that NaN fails the check as well
+	if !(options.Probability >= 0 && options.Probability < 1) {
+		return nil, ErrInvalidProbability
+	}
+
+	if options.Comparator == nil {
+		return nil, ErrNoComparator
+	}
+
+	list := NewWithComparator[K, V](options.MaxLevel, options.Comparator)
+	if options.Probability != 0 {
+		list.promote = int32((1 - options.Probability) * math.MaxInt32)
+	}
+
+	if options.Source != nil {
+		list.rand = rand.New(options.Source)
+	}
+
+	if options.Clock != nil {
+		list.now = options.Clock
+	}
+
+	if options.MaxEntries > 0 {
+		list.maxEntries = options.MaxEntries
+		list.recency = &SkipListNode[K, V]{}
+		list.resetRecency()
+	}
+
+	list.growable = options.Growable
+	list.multiset = options.Multiset
+	list.levelFunc = options.LevelFunc
+	list.cacheLast = options.CacheLastAccess
+	if options.PoolNodes {
+		list.nodePool = newNodePool()
+	}
+	list.keySize = options.KeySize
+	list.valueSize = options.ValueSize
+	list.copyFn = options.CopyValue
+	list.storeFn = options.StoreValue
+	return list, nil
+}
@@ -0,0 +1,112 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generic
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	list, err := NewWithOptions(Options[string, int]{MaxLevel: 5, Comparator: strings.Compare})
+	assert.Nil(t, err)
+	if assert.NotNil(t, list) {
+		assert.Equal(t, list.MaxLevel(), 5)
+		assert.Equal(t, list.promote, int32(defaultPromote))
+	}
+
+	list, err = NewWithOptions(Options[string, int]{MaxLevel: 5})
+	assert.Nil(t, list)
+	assert.Equal(t, err, ErrNoComparator)
+
+	for _, probability := range []float64{-0.5, 1, math.NaN()} {
+		list, err = NewWithOptions(Options[string, int]{MaxLevel: 5, Comparator: strings.Compare, Probability: probability})
+		assert.Nil(t, list)
+		assert.Equal(t, err, ErrInvalidProbability)
+	}
+}
+
+func TestValueHooks(t *testing.T) {
+	stores, copies := 0, 0
+	list, err := NewWithOptions(Options[string, []byte]{
+		MaxLevel:   5,
+		Comparator: strings.Compare,
+		KeySize:    func(key string) int { return len(key) },
+		ValueSize:  func(value []byte) int { return len(value) },
+		CopyValue: func(value []byte) []byte {
+			copies++
+			return append([]byte(nil), value...)
+		},
+		StoreValue: func(value []byte) []byte {
+			stores++
+			return append([]byte(nil), value...)
+		},
+	})
+	assert.Nil(t, err)
+
+	value := []byte("value")
+	list.Set("key", value)
+	value[0] = 'X'
+	assert.Equal(t, stores, 1)
+	assert.Equal(t, list.Size(), uint64(8))
+	assert.Equal(t, list.MaxKeyLen(), 3)
+	assert.Equal(t, list.MaxValueLen(), 5)
+
+	loaded, ok := list.Load("key")
+	assert.True(t, ok)
+	assert.Equal(t, loaded, []byte("value"))
+	loaded[0] = 'X'
+	assert.Equal(t, list.Values(), [][]byte{[]byte("value")})
+	assert.Equal(t, copies, 2)
+
+	// without hooks values are kept as they are and measure as 0
+	plain := New[string, []byte](5)
+	plain.Set("key", value)
+	value[1] = 'Y'
+	assert.Equal(t, plain.Get("key").Value(), []byte("XYlue"))
+	assert.Equal(t, plain.Size(), uint64(0))
+}
+
+func TestCacheLastAccess(t *testing.T) {
+	list, err := NewWithOptions(Options[string, int]{MaxLevel: 10, Comparator: strings.Compare, CacheLastAccess: true})
+	assert.Nil(t, err)
+
+	for i := 0; i < 100; i++ {
+		list.Set(strconv.Itoa(i), i)
+	}
+
+	assert.Equal(t, list.Get("42").Value(), 42)
+	assert.Equal(t, list.lastAccess.Load().Key(), "42")
+	assert.Equal(t, list.Get("43").Value(), 43)
+	assert.Equal(t, list.lastAccess.Load().Key(), "43")
+
+	list.Remove("43")
+	assert.Nil(t, list.lastAccess.Load())
+	assert.Nil(t, list.Get("43"))
+}
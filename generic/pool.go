@@ -0,0 +1,85 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generic
+
+import (
+	"sync"
+)
+
+func newNodePool() *sync.Pool {
+	return &sync.Pool{}
+}
+
+// newNode returns a recycled node when pooling is enabled. A recycled node is
+// reset here rather than when it is released, since a caller may still write
+// to a node that an eviction released in the same call.
+func (list *SkipList[K, V]) newNode() *SkipListNode[K, V] {
+	if list.nodePool != nil {
+		if node, ok := list.nodePool.Get().(*SkipListNode[K, V]); ok {
+			*node = SkipListNode[K, V]{
+				prevNode: node.prevNode[:0],
+				nextNode: node.nextNode[:0],
+				span:     node.span[:0],
+			}
+			return node
+		}
+	}
+	return &SkipListNode[K, V]{}
+}
+
+// recycle queues an unlinked node for the pool. Nodes only go back when the
+// lock is released, since the operation that unlinked a node, such as an
+// eviction inside an insert, may still read it. Nodes waiting for the remove
+// hook are kept.
+func (list *SkipList[K, V]) recycle(node *SkipListNode[K, V]) {
+	if list.nodePool == nil || list.onRemove != nil {
+		return
+	}
+	list.recycled = append(list.recycled, node)
+}
+
+// flushRecycled hands the queued nodes to the pool, clearing their links so
+// the pool does not keep removed neighbours alive. The caller must hold the
+// write lock.
+func (list *SkipList[K, V]) flushRecycled() {
+	for _, node := range list.recycled {
+		clear(node.prevNode)
+		clear(node.nextNode)
+		node.item = SkipListItem[K, V]{}
+		list.nodePool.Put(node)
+	}
+
+	clear(list.recycled)
+	list.recycled = list.recycled[:0]
+}
+
+// withLen returns a slice of length n, reusing the capacity of s when it is
+// large enough.
+func withLen[T any](s []T, n int) []T {
+	if cap(s) >= n {
+		return s[:n]
+	}
+	return make([]T, n)
+}
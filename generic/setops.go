@@ -0,0 +1,134 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generic
+
+// Intersection returns a new list with the keys present in both lists. The
+// values and settings come from the receiver.
+func (list *SkipList[K, V]) Intersection(other *SkipList[K, V]) *SkipList[K, V] {
+	items := other.snapshot()
+
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	result := list.emptyCopy()
+	node := list.head.nextNode[0]
+	for i := 0; !node.isEndNode && i < len(items); {
+		switch compared := list.compare(node.item.key, items[i].key); {
+		case compared < 0:
+			node = node.nextNode[0]
+		case compared > 0:
+			i++
+		default:
+			result.appendInternal(node.item.key, node.item.value).expireAt = node.expireAt
+			node = node.nextNode[0]
+			i++
+		}
+	}
+
+	result.storeFn = list.storeFn
+	return result
+}
+
+// Union returns a new list with the keys present in either list. When both
+// hold a key the receiver's value wins. Settings come from the receiver.
+func (list *SkipList[K, V]) Union(other *SkipList[K, V]) *SkipList[K, V] {
+	items := other.snapshot()
+
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	result := list.emptyCopy()
+	node := list.head.nextNode[0]
+	i := 0
+	for !node.isEndNode || i < len(items) {
+		compared := -1
+		if node.isEndNode {
+			compared = 1
+		} else if i < len(items) {
+			compared = list.compare(node.item.key, items[i].key)
+		}
+
+		if compared > 0 {
+			result.appendInternal(items[i].key, items[i].value)
+			i++
+			continue
+		}
+
+		result.appendInternal(node.item.key, node.item.value).expireAt = node.expireAt
+		node = node.nextNode[0]
+		if compared == 0 {
+			i++
+		}
+	}
+
+	result.storeFn = list.storeFn
+	return result
+}
+
+// Difference returns a new list with the keys of the receiver that are absent
+// from other. Settings come from the receiver.
+func (list *SkipList[K, V]) Difference(other *SkipList[K, V]) *SkipList[K, V] {
+	items := other.snapshot()
+
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	result := list.emptyCopy()
+	node := list.head.nextNode[0]
+	i := 0
+	for !node.isEndNode {
+		compared := -1
+		if i < len(items) {
+			compared = list.compare(node.item.key, items[i].key)
+		}
+
+		switch {
+		case compared < 0:
+			result.appendInternal(node.item.key, node.item.value).expireAt = node.expireAt
+			node = node.nextNode[0]
+		case compared > 0:
+			i++
+		default:
+			node = node.nextNode[0]
+			i++
+		}
+	}
+
+	result.storeFn = list.storeFn
+	return result
+}
+
+// snapshot returns the items of the list in key order, taken under its read
+// lock so it can be combined with another list without holding both locks.
+func (list *SkipList[K, V]) snapshot() []SkipListItem[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	items := make([]SkipListItem[K, V], 0, list.length)
+	for node := list.head.nextNode[0]; !node.isEndNode; node = node.nextNode[0] {
+		items = append(items, node.item)
+	}
+	return items
+}
@@ -0,0 +1,1819 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package generic implement skip list data structure over any key and value
+// type. It is the engine behind the root skiplist package, which wraps
+// SkipList[string, []byte].
+// Reference: https://en.wikipedia.org/wiki/Skip_list
+
+// Example
+
+// list := generic.New[int, string](5)
+// list.Set(42, "value")
+
+// item := list.Get(42)
+// fmt.Printf("key : %d / value : %s", item.Key(), item.Value())
+
+package generic
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+const (
+	minLevel       = 1
+	defaultPromote = 1 << 30
+)
+
+var ErrIndexOutOfRange = errors.New("skiplist: index out of range")
+
+var ErrOutOfOrder = errors.New("skiplist: key is smaller than the last key")
+
+var ErrLevelOutOfRange = errors.New("skiplist: level must be between 1 and the max level")
+
+// Key is implemented by key types that define their own order, such as
+// composite struct keys.
+type Key[K any] interface {
+	Less(other K) bool
+}
+
+type SkipListItem[K any, V any] struct {
+	key   K
+	value V
+}
+
+func (item *SkipListItem[K, V]) Key() K {
+	return item.key
+}
+
+func (item *SkipListItem[K, V]) Value() V {
+	return item.value
+}
+
+func NewItem[K any, V any](key K, value V) SkipListItem[K, V] {
+	return SkipListItem[K, V]{key: key, value: value}
+}
+
+type SkipListNode[K any, V any] struct {
+	levels    int
+	prevNode  []*SkipListNode[K, V]
+	nextNode  []*SkipListNode[K, V]
+	span      []int
+	item      SkipListItem[K, V]
+	expireAt  time.Time
+	lruPrev   *SkipListNode[K, V]
+	lruNext   *SkipListNode[K, V]
+	isEndNode bool
+}
+
+func (node *SkipListNode[K, V]) Next() *SkipListNode[K, V] {
+	if node.nextNode[0] != nil && node.nextNode[0].isEndNode {
+		return nil
+	}
+	return node.nextNode[0]
+}
+
+func (node *SkipListNode[K, V]) Prev() *SkipListNode[K, V] {
+	if node.prevNode[0] != nil && node.prevNode[0].isEndNode {
+		return nil
+	}
+	return node.prevNode[0]
+}
+
+func (node *SkipListNode[K, V]) Key() K {
+	return node.item.key
+}
+
+func (node *SkipListNode[K, V]) Value() V {
+	return node.item.value
+}
+
+func (node *SkipListNode[K, V]) next(targetLevel int) *SkipListNode[K, V] {
+	if targetLevel >= node.nodeLevel() {
+		return nil
+	}
+	return node.nextNode[targetLevel]
+}
+
+func (node *SkipListNode[K, V]) match(key K, compare func(a, b K) int) bool {
+	return compare(key, node.item.key) == 0
+}
+
+func (node *SkipListNode[K, V]) nodeLevel() int {
+	return node.levels
+}
+
+func (node *SkipListNode[K, V]) appendOnLevel(newNode *SkipListNode[K, V], targetLevel int) {
+	if node.nextNode[targetLevel] != nil {
+		node.nextNode[targetLevel].prevNode[targetLevel] = newNode
+	}
+
+	newNode.prevNode[targetLevel] = node
+	newNode.nextNode[targetLevel] = node.nextNode[targetLevel]
+
+	node.nextNode[targetLevel] = newNode
+}
+
+func (node *SkipListNode[K, V]) removeOnLevel(targetLevel int) {
+	if node.nextNode[targetLevel] != nil {
+		node.nextNode[targetLevel].prevNode[targetLevel] = node.prevNode[targetLevel]
+	}
+
+	if node.prevNode[targetLevel] != nil {
+		node.prevNode[targetLevel].nextNode[targetLevel] = node.nextNode[targetLevel]
+	}
+}
+
+type SkipList[K any, V any] struct {
+	maxLevel  int
+	length    int
+	size      uint64
+	head      *SkipListNode[K, V]
+	tail      *SkipListNode[K, V]
+	rand      *rand.Rand
+	promote   int32
+	levelFunc func() int
+	growable  bool
+	multiset  bool
+	mutex     sync.RWMutex
+	compare   func(a, b K) int
+	now       func() time.Time
+	counters  counters
+
+	keySize   func(key K) int
+	valueSize func(value V) int
+	copyFn    func(value V) V
+	storeFn   func(value V) V
+
+	maxKeyLen   int
+	maxValueLen int
+
+	maxEntries int
+	maxBytes   uint64
+	recency    *SkipListNode[K, V]
+	lruMutex   sync.Mutex
+
+	onRemove func(key K, value V)
+	retired  []*SkipListNode[K, V]
+
+	expiryMutex sync.Mutex
+	expiryStop  chan struct{}
+	expiryDone  chan struct{}
+
+	cacheLast  bool
+	lastAccess atomic.Pointer[SkipListNode[K, V]]
+
+	nodePool *sync.Pool
+	recycled []*SkipListNode[K, V]
+
+	// sampleMutex guards rand for RandomNode, which only holds the read lock
+	sampleMutex sync.Mutex
+}
+
+func New[K cmp.Ordered, V any](maxLevel int) *SkipList[K, V] {
+	return NewWithComparator[K, V](maxLevel, cmp.Compare[K])
+}
+
+// NewWithLess creates a list ordered by the Less method of its keys. Two keys
+// are equal when neither is less than the other.
+func NewWithLess[K Key[K], V any](maxLevel int) *SkipList[K, V] {
+	return NewWithComparator[K, V](maxLevel, func(a, b K) int {
+		if a.Less(b) {
+			return -1
+		}
+		if b.Less(a) {
+			return 1
+		}
+		return 0
+	})
+}
+
+func NewWithComparator[K any, V any](maxLevel int, compare func(a, b K) int) *SkipList[K, V] {
+	if maxLevel < minLevel {
+		maxLevel = minLevel
+	}
+
+	list := SkipList[K, V]{
+		maxLevel: maxLevel,
+		length:   0,
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		promote:  defaultPromote,
+		compare:  compare,
+		now:      time.Now,
+	}
+
+	list.head, list.tail = newEndNodes[K, V](maxLevel)
+	return &list
+}
+
+func NewWithRand[K cmp.Ordered, V any](maxLevel int, src rand.Source) *SkipList[K, V] {
+	list := New[K, V](maxLevel)
+	list.rand = rand.New(src)
+	return list
+}
+
+func newEndNodes[K any, V any](maxLevel int) (*SkipListNode[K, V], *SkipListNode[K, V]) {
+	headNode := &SkipListNode[K, V]{
+		levels:    maxLevel,
+		prevNode:  make([]*SkipListNode[K, V], maxLevel),
+		nextNode:  make([]*SkipListNode[K, V], maxLevel),
+		span:      make([]int, maxLevel),
+		isEndNode: true,
+	}
+
+	tailNode := &SkipListNode[K, V]{
+		levels:    maxLevel,
+		prevNode:  make([]*SkipListNode[K, V], maxLevel),
+		nextNode:  make([]*SkipListNode[K, V], maxLevel),
+		span:      make([]int, maxLevel),
+		isEndNode: true,
+	}
+
+	for i := 0; i < maxLevel; i++ {
+		headNode.appendOnLevel(tailNode, i)
+		headNode.span[i] = 1
+	}
+
+	return headNode, tailNode
+}
+
+func (list *SkipList[K, V]) MaxLevel() int {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.maxLevel
+}
+
+func (list *SkipList[K, V]) Length() int {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.length
+}
+
+// Size returns the total size of the keys and values as measured by the
+// KeySize and ValueSize options. It is 0 when they are not set.
+func (list *SkipList[K, V]) Size() uint64 {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.size
+}
+
+// MaxKeyLen returns the size of the largest key stored since the list was
+// created or last cleared. Removing keys does not lower it; Compact
+// recomputes it from the remaining entries.
+func (list *SkipList[K, V]) MaxKeyLen() int {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.maxKeyLen
+}
+
+// MaxValueLen returns the size of the largest value stored, with the same
+// rules as MaxKeyLen.
+func (list *SkipList[K, V]) MaxValueLen() int {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.maxValueLen
+}
+
+// ApproxMemoryBytes estimates the memory held by the list, including the
+// per-node struct and pointer slices that Size leaves out.
+func (list *SkipList[K, V]) ApproxMemoryBytes() uint64 {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	total := uint64(unsafe.Sizeof(*list))
+	total += list.nodeMemoryBytes(list.head) + list.nodeMemoryBytes(list.tail)
+	for node := list.head.nextNode[0]; !node.isEndNode; node = node.nextNode[0] {
+		total += list.nodeMemoryBytes(node)
+	}
+	return total
+}
+
+func (list *SkipList[K, V]) LevelHistogram() []int {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	histogram := make([]int, list.maxLevel)
+	for node := list.head.nextNode[0]; !node.isEndNode; node = node.nextNode[0] {
+		histogram[node.nodeLevel()-1]++
+	}
+	return histogram
+}
+
+func (list *SkipList[K, V]) String() string {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	builder := strings.Builder{}
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		fmt.Fprintf(&builder, "level %d:", i)
+		for node := list.head.nextNode[i]; !node.isEndNode; node = node.nextNode[i] {
+			fmt.Fprintf(&builder, " %v", node.item.key)
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+func (list *SkipList[K, V]) IsEmpty() bool {
+	return list.Length() == 0
+}
+
+// Front returns the node with the smallest key, or nil if the list is empty.
+func (list *SkipList[K, V]) Front() *SkipListNode[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	node := list.head.nextNode[0]
+	if node.isEndNode {
+		return nil
+	}
+	return node
+}
+
+// Back returns the node with the largest key, or nil if the list is empty.
+func (list *SkipList[K, V]) Back() *SkipListNode[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	node := list.tail.prevNode[0]
+	if node.isEndNode {
+		return nil
+	}
+	return node
+}
+
+func (list *SkipList[K, V]) Min() *SkipListItem[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	node := list.head.nextNode[0]
+	if node.isEndNode {
+		return nil
+	}
+	return &node.item
+}
+
+func (list *SkipList[K, V]) Max() *SkipListItem[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	node := list.tail.prevNode[0]
+	if node.isEndNode {
+		return nil
+	}
+	return &node.item
+}
+
+// First returns copies of up to n items with the smallest keys, in ascending order.
+func (list *SkipList[K, V]) First(n int) []*SkipListItem[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	items := make([]*SkipListItem[K, V], 0, clamp(n, 0, list.length))
+	for node := list.head.nextNode[0]; !node.isEndNode && len(items) < n; node = node.nextNode[0] {
+		items = append(items, &SkipListItem[K, V]{key: node.item.key, value: list.copyValue(node.item.value)})
+	}
+	return items
+}
+
+// Last returns copies of up to n items with the largest keys, in ascending order.
+func (list *SkipList[K, V]) Last(n int) []*SkipListItem[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	items := make([]*SkipListItem[K, V], clamp(n, 0, list.length))
+	node := list.tail.prevNode[0]
+	for i := len(items) - 1; i >= 0; i-- {
+		items[i] = &SkipListItem[K, V]{key: node.item.key, value: list.copyValue(node.item.value)}
+		node = node.prevNode[0]
+	}
+	return items
+}
+
+func (list *SkipList[K, V]) Keys() []K {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	keys := make([]K, 0, list.length)
+	for node := list.head.nextNode[0]; !node.isEndNode; node = node.nextNode[0] {
+		keys = append(keys, node.item.key)
+	}
+	return keys
+}
+
+// KeysPage returns up to limit keys strictly greater than after, in order.
+// A nil after starts from the first key, so the last key of a page can be
+// passed back in to fetch the next one.
+func (list *SkipList[K, V]) KeysPage(after *K, limit int) []K {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	node := list.head.nextNode[0]
+	if after != nil {
+		node = list.findGreaterOrEqual(*after, nil)
+		for !node.isEndNode && node.match(*after, list.compare) {
+			node = node.nextNode[0]
+		}
+	}
+
+	keys := make([]K, 0, clamp(limit, 0, list.length))
+	for ; !node.isEndNode && len(keys) < limit; node = node.nextNode[0] {
+		keys = append(keys, node.item.key)
+	}
+	return keys
+}
+
+func (list *SkipList[K, V]) Values() []V {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	values := make([]V, 0, list.length)
+	for node := list.head.nextNode[0]; !node.isEndNode; node = node.nextNode[0] {
+		values = append(values, list.copyValue(node.item.value))
+	}
+	return values
+}
+
+// Set stores value under key, passed through the StoreValue option when it is
+// set.
+func (list *SkipList[K, V]) Set(key K, value V) {
+	list.SetAndGet(key, value)
+}
+
+// SetWithLevel works like Set but gives a newly inserted node exactly level
+// levels instead of a random height. An existing node of another height is
+// relinked at level. It returns ErrLevelOutOfRange unless level is between 1
+// and MaxLevel.
+func (list *SkipList[K, V]) SetWithLevel(key K, value V, level int) error {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	if level < minLevel || level > list.maxLevel {
+		return ErrLevelOutOfRange
+	}
+
+	list.counters.sets.Add(1)
+	if list.multiset {
+		list.insertLastAt(key, value, level)
+		return nil
+	}
+
+	history := make([]*SkipListNode[K, V], list.maxLevel)
+	node := list.findInternal(key, history)
+	if node != nil && node.nodeLevel() == level {
+		list.updateNode(node, value)
+		return nil
+	}
+
+	if node != nil {
+		// the key stays stored, so this is not a removal for the hook
+		list.forget(node)
+		list.lastAccess.CompareAndSwap(node, nil)
+		list.unlinkNode(node)
+	}
+
+	list.insertNodeAt(key, value, level, history)
+	return nil
+}
+
+// TrySet works like Set but gives up and returns false if the write lock
+// cannot be acquired within timeout.
+func (list *SkipList[K, V]) TrySet(key K, value V, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	wait := 10 * time.Microsecond
+	for !list.mutex.TryLock() {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return false
+		}
+
+		time.Sleep(min(wait, remaining))
+		wait = min(wait*2, time.Millisecond)
+	}
+	defer list.unlock()
+
+	list.setInternal(key, value)
+	return true
+}
+
+func (list *SkipList[K, V]) SetAndGet(key K, value V) (V, bool) {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	return list.setInternal(key, value)
+}
+
+func (list *SkipList[K, V]) GetOrSet(key K, value V) (V, bool) {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	history := make([]*SkipListNode[K, V], list.maxLevel)
+	node := list.findInternal(key, history)
+	if node != nil && !list.expired(node) {
+		return node.item.value, true
+	}
+
+	list.counters.sets.Add(1)
+	if node != nil {
+		list.updateNode(node, value)
+		return node.item.value, false
+	}
+
+	node = list.insertNode(key, value, history)
+	return node.item.value, false
+}
+
+func (list *SkipList[K, V]) SetIfAbsent(key K, value V) bool {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	history := make([]*SkipListNode[K, V], list.maxLevel)
+	node := list.findInternal(key, history)
+	if node != nil && !list.expired(node) {
+		return false
+	}
+
+	list.counters.sets.Add(1)
+	if node != nil {
+		list.updateNode(node, value)
+		return true
+	}
+
+	list.insertNode(key, value, history)
+	return true
+}
+
+// SetWithKeyCopy works like Set but stores copyKey(key) when it inserts a new
+// entry. Lookups and updates use key as it is, so key may borrow memory the
+// caller reuses and is only copied when the list keeps it.
+func (list *SkipList[K, V]) SetWithKeyCopy(key K, value V, copyKey func(key K) K) {
+	list.counters.sets.Add(1)
+
+	list.mutex.Lock()
+	defer list.unlock()
+
+	if list.multiset {
+		list.insertLastInternal(copyKey(key), value)
+		return
+	}
+
+	history := make([]*SkipListNode[K, V], list.maxLevel)
+	if node := list.findInternal(key, history); node != nil {
+		list.updateNode(node, value)
+		return
+	}
+	list.insertNode(copyKey(key), value, history)
+}
+
+func (list *SkipList[K, V]) SetBatch(items []SkipListItem[K, V]) {
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+
+	// ties are broken by position so the last duplicate in items wins
+	slices.SortFunc(order, func(a, b int) int {
+		if result := list.compare(items[a].key, items[b].key); result != 0 {
+			return result
+		}
+		return a - b
+	})
+
+	list.mutex.Lock()
+	defer list.unlock()
+
+	list.counters.sets.Add(uint64(len(items)))
+	if list.multiset {
+		for _, index := range order {
+			list.insertLastInternal(items[index].key, items[index].value)
+		}
+		return
+	}
+
+	history := make([]*SkipListNode[K, V], list.maxLevel)
+	for i := range history {
+		history[i] = list.head
+	}
+
+	for _, index := range order {
+		for len(history) < list.maxLevel {
+			history = append(history, list.head)
+		}
+
+		item := items[index]
+		node := list.findFromInternal(item.key, history)
+		if node != nil {
+			list.updateNode(node, item.value)
+		} else {
+			list.insertNode(item.key, item.value, history)
+		}
+
+		if list.evicting() {
+			// an eviction may have unlinked a node on the search path
+			for i := range history {
+				history[i] = list.head
+			}
+		}
+	}
+}
+
+// AppendSorted sets key when it is not smaller than the largest key in the
+// list. The new node is linked in front of the tail without searching, which
+// makes loading already sorted data cheaper than Set. A smaller key is
+// rejected with ErrOutOfOrder.
+func (list *SkipList[K, V]) AppendSorted(key K, value V) error {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	last := list.tail.prevNode[0]
+	result := -1
+	if !last.isEndNode {
+		result = list.compare(last.item.key, key)
+	}
+	if result > 0 {
+		return ErrOutOfOrder
+	}
+
+	list.counters.sets.Add(1)
+	if result == 0 && !list.multiset {
+		list.updateNode(last, value)
+		return nil
+	}
+
+	history := make([]*SkipListNode[K, V], list.maxLevel)
+	copy(history, list.tail.prevNode)
+	list.insertNode(key, value, history)
+	return nil
+}
+
+// Update replaces the value of key with the result of fn, which receives a
+// copy of the current value. It returns false if key is absent.
+func (list *SkipList[K, V]) Update(key K, fn func(old V) V) bool {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	node := list.findInternal(key, nil)
+	if node == nil || list.expired(node) {
+		return false
+	}
+
+	list.replaceValue(node, fn(list.copyValue(node.item.value)))
+	return true
+}
+
+// Modify replaces the value of key with the result of fn, which receives the
+// stored value itself and may change it in place. Neither value is passed
+// through CopyValue or StoreValue. It returns false if key is absent.
+func (list *SkipList[K, V]) Modify(key K, fn func(value V) V) bool {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	node := list.findInternal(key, nil)
+	if node == nil || list.expired(node) {
+		return false
+	}
+
+	old := node.item.value
+	node.item.value = fn(old)
+	list.resizeValue(old, node.item.value)
+	list.touch(node)
+	list.evictInternal()
+	return true
+}
+
+// ReplaceValue sets the value of key only if key is present, keeping its
+// expiry. It returns false without inserting when key is absent.
+func (list *SkipList[K, V]) ReplaceValue(key K, value V) bool {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	node := list.findInternal(key, nil)
+	if node == nil || list.expired(node) {
+		return false
+	}
+
+	list.replaceValue(node, value)
+	return true
+}
+
+// CompareAndSwapFunc replaces the value of key with new only if equal reports
+// that it currently equals old. It returns false if key is absent or holds
+// another value.
+func (list *SkipList[K, V]) CompareAndSwapFunc(key K, old, new V, equal func(a, b V) bool) bool {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	node := list.findInternal(key, nil)
+	if node == nil || list.expired(node) || !equal(node.item.value, old) {
+		return false
+	}
+
+	list.replaceValue(node, new)
+	return true
+}
+
+// Swap exchanges the values of key1 and key2. The keys keep their positions
+// and expiry. It returns false if either key is absent.
+func (list *SkipList[K, V]) Swap(key1, key2 K) bool {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	node1 := list.findInternal(key1, nil)
+	if node1 == nil || list.expired(node1) {
+		return false
+	}
+
+	node2 := list.findInternal(key2, nil)
+	if node2 == nil || list.expired(node2) {
+		return false
+	}
+
+	// the values only change owners, so size stays the same
+	node1.item.value, node2.item.value = node2.item.value, node1.item.value
+	list.touch(node1)
+	list.touch(node2)
+	return true
+}
+
+// Get returns the stored item for key. The item points into the list, so
+// modifying its value changes the stored data; use Load for a safe copy.
+func (list *SkipList[K, V]) Get(key K) *SkipListItem[K, V] {
+	node := list.findLive(key, true)
+	list.counters.recordGet(node != nil)
+	if node == nil {
+		return nil
+	}
+	return &node.item
+}
+
+// Load returns the value stored under key, passed through the CopyValue
+// option when it is set.
+func (list *SkipList[K, V]) Load(key K) (V, bool) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	node := list.findInternal(key, nil)
+	if node == nil || list.expired(node) {
+		list.counters.recordGet(false)
+		var zero V
+		return zero, false
+	}
+
+	list.counters.recordGet(true)
+	list.touch(node)
+	return list.copyValue(node.item.value), true
+}
+
+// GetMulti looks up all keys under one read lock and returns copies of their
+// values in the order of keys, with the zero value for missing keys. The keys
+// are sorted first so the list is walked forward only once.
+func (list *SkipList[K, V]) GetMulti(keys []K) []V {
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int {
+		return list.compare(keys[a], keys[b])
+	})
+
+	values := make([]V, len(keys))
+
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	history := make([]*SkipListNode[K, V], list.maxLevel)
+	for i := range history {
+		history[i] = list.head
+	}
+
+	for _, index := range order {
+		node := list.findFromInternal(keys[index], history)
+		hit := node != nil && !list.expired(node)
+		list.counters.recordGet(hit)
+		if hit {
+			list.touch(node)
+			values[index] = list.copyValue(node.item.value)
+		}
+	}
+	return values
+}
+
+// FindWithSteps looks up key like Get and also returns the number of forward
+// hops the search made, which is useful for profiling slow lookups.
+func (list *SkipList[K, V]) FindWithSteps(key K) (*SkipListItem[K, V], int) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	node, steps := list.searchSteps(key)
+	if node.isEndNode || !node.match(key, list.compare) || list.expired(node) {
+		return nil, steps
+	}
+	return &node.item, steps
+}
+
+// searchSteps works like findGreaterOrEqual and also counts the forward hops.
+func (list *SkipList[K, V]) searchSteps(key K) (*SkipListNode[K, V], int) {
+	steps := 0
+	current := list.head
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		for list.before(current.next(i), key) {
+			current = current.next(i)
+			steps++
+		}
+	}
+	return current.next(0), steps
+}
+
+// NodeLevel returns the height of the node holding key, which helps to
+// explain slow lookups. It returns false if key is absent.
+func (list *SkipList[K, V]) NodeLevel(key K) (int, bool) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	node := list.findInternal(key, nil)
+	if node == nil || list.expired(node) {
+		return 0, false
+	}
+	return node.nodeLevel(), true
+}
+
+func (list *SkipList[K, V]) Contains(key K) bool {
+	return list.findLive(key, false) != nil
+}
+
+func (list *SkipList[K, V]) Remove(key K) bool {
+	list.counters.removes.Add(1)
+
+	list.mutex.Lock()
+	defer list.unlock()
+
+	node := list.findInternal(key, nil)
+	if node == nil {
+		return false
+	}
+
+	list.deleteNode(node)
+	return true
+}
+
+func (list *SkipList[K, V]) PopMin() (*SkipListItem[K, V], bool) {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	return list.popInternal(list.head.nextNode[0])
+}
+
+func (list *SkipList[K, V]) PopMax() (*SkipListItem[K, V], bool) {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	return list.popInternal(list.tail.prevNode[0])
+}
+
+// RemoveFront removes and returns the first item. Like PopMin it unlinks the
+// node directly, without searching.
+func (list *SkipList[K, V]) RemoveFront() (*SkipListItem[K, V], bool) {
+	return list.PopMin()
+}
+
+// RemoveBack removes and returns the last item. Like PopMax it unlinks the
+// node directly, without searching.
+func (list *SkipList[K, V]) RemoveBack() (*SkipListItem[K, V], bool) {
+	return list.PopMax()
+}
+
+func (list *SkipList[K, V]) Range(start, end K, fn func(node *SkipListNode[K, V]) bool) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	if list.compare(start, end) > 0 {
+		return
+	}
+
+	for node := list.findGreaterOrEqual(start, nil); !node.isEndNode; node = node.nextNode[0] {
+		if list.compare(node.item.key, end) >= 0 || !fn(node) {
+			return
+		}
+	}
+}
+
+// RangeFrom calls fn for every node with a key >= start, in order, stopping
+// early if fn returns false.
+func (list *SkipList[K, V]) RangeFrom(start K, fn func(node *SkipListNode[K, V]) bool) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	for node := list.findGreaterOrEqual(start, nil); !node.isEndNode; node = node.nextNode[0] {
+		if !fn(node) {
+			return
+		}
+	}
+}
+
+// EqualRange returns every node with key, in insertion order when the list
+// is a multiset.
+func (list *SkipList[K, V]) EqualRange(key K) []*SkipListNode[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	var nodes []*SkipListNode[K, V]
+	for node := list.findGreaterOrEqual(key, nil); !node.isEndNode && node.match(key, list.compare); node = node.nextNode[0] {
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// ForEach calls fn for every entry in key order with a copy of the value,
+// stopping early if fn returns false. fn must not modify the list.
+func (list *SkipList[K, V]) ForEach(fn func(key K, value V) bool) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	list.forEachInternal(fn)
+}
+
+func (list *SkipList[K, V]) forEachInternal(fn func(key K, value V) bool) {
+	for node := list.head.nextNode[0]; !node.isEndNode; node = node.nextNode[0] {
+		if !fn(node.item.key, list.copyValue(node.item.value)) {
+			return
+		}
+	}
+}
+
+const contextCheckInterval = 1024
+
+// RangeContext works like Range but checks ctx every 1024 nodes and returns
+// its error if the scan was cut short.
+func (list *SkipList[K, V]) RangeContext(ctx context.Context, start, end K, fn func(node *SkipListNode[K, V]) bool) error {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	if list.compare(start, end) > 0 {
+		return ctx.Err()
+	}
+
+	count := 0
+	for node := list.findGreaterOrEqual(start, nil); !node.isEndNode; node = node.nextNode[0] {
+		if count%contextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		count++
+
+		if list.compare(node.item.key, end) >= 0 || !fn(node) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// ForEachContext works like ForEach but checks ctx every 1024 nodes and
+// returns its error if the scan was cut short.
+func (list *SkipList[K, V]) ForEachContext(ctx context.Context, fn func(key K, value V) bool) error {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	count := 0
+	for node := list.head.nextNode[0]; !node.isEndNode; node = node.nextNode[0] {
+		if count%contextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		count++
+
+		if !fn(node.item.key, list.copyValue(node.item.value)) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (list *SkipList[K, V]) Ceil(key K) *SkipListNode[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	node := list.findGreaterOrEqual(key, nil)
+	if node.isEndNode {
+		return nil
+	}
+	return node
+}
+
+func (list *SkipList[K, V]) Floor(key K) *SkipListNode[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	node := list.findLessOrEqual(key)
+	if node.isEndNode {
+		return nil
+	}
+	return node
+}
+
+// CeilItem returns the item with the smallest key >= the argument. exact is
+// true only when that key equals the argument.
+func (list *SkipList[K, V]) CeilItem(key K) (*SkipListItem[K, V], bool) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	node := list.findGreaterOrEqual(key, nil)
+	if node.isEndNode {
+		return nil, false
+	}
+	return &node.item, node.match(key, list.compare)
+}
+
+// FloorItem returns the item with the largest key <= the argument. exact is
+// true only when that key equals the argument.
+func (list *SkipList[K, V]) FloorItem(key K) (*SkipListItem[K, V], bool) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	node := list.findLessOrEqual(key)
+	if node.isEndNode {
+		return nil, false
+	}
+	return &node.item, node.match(key, list.compare)
+}
+
+// SeekForPrev returns the largest node with key <= the argument, so that the
+// list can be walked backward with Prev. It is equivalent to Floor.
+func (list *SkipList[K, V]) SeekForPrev(key K) *SkipListNode[K, V] {
+	return list.Floor(key)
+}
+
+func (list *SkipList[K, V]) Rank(key K) (int, bool) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	rank, node := list.rankInternal(key)
+	if node.isEndNode || !node.match(key, list.compare) {
+		return 0, false
+	}
+	return rank, true
+}
+
+// CountRange returns the number of keys in [start, end).
+func (list *SkipList[K, V]) CountRange(start, end K) int {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	startRank, _ := list.rankInternal(start)
+	endRank, _ := list.rankInternal(end)
+	if endRank < startRank {
+		return 0
+	}
+	return endRank - startRank
+}
+
+// CountFrom returns the number of keys >= start.
+func (list *SkipList[K, V]) CountFrom(start K) int {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	startRank, _ := list.rankInternal(start)
+	return list.length - startRank
+}
+
+// Select returns the node at zero based position n in key order, or nil if n
+// is negative or not less than the length.
+func (list *SkipList[K, V]) Select(n int) *SkipListNode[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	if n < 0 || n >= list.length {
+		return nil
+	}
+	return list.selectInternal(n)
+}
+
+// SelectItem works like Select but returns the item and ErrIndexOutOfRange
+// when n is negative or not less than the length.
+func (list *SkipList[K, V]) SelectItem(n int) (*SkipListItem[K, V], error) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	if n < 0 || n >= list.length {
+		return nil, ErrIndexOutOfRange
+	}
+	return &list.selectInternal(n).item, nil
+}
+
+// RandomNode returns a node chosen uniformly at random, or nil if the list is
+// empty. It picks a random position and finds it through the spans, so it
+// runs in O(log n). Positions come from the list's random source, so lists
+// made with the same Source sample the same way.
+func (list *SkipList[K, V]) RandomNode() *SkipListNode[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	if list.length == 0 {
+		return nil
+	}
+
+	// writers use the source under the write lock, readers take sampleMutex
+	list.sampleMutex.Lock()
+	n := list.rand.Intn(list.length)
+	list.sampleMutex.Unlock()
+
+	return list.selectInternal(n)
+}
+
+// DeleteNth removes the item at zero based position n in key order and
+// returns it. It returns false if n is out of range.
+func (list *SkipList[K, V]) DeleteNth(n int) (*SkipListItem[K, V], bool) {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	if n < 0 || n >= list.length {
+		return nil, false
+	}
+	return list.popInternal(list.selectInternal(n))
+}
+
+// DeleteRange removes every key in [start, end) and returns how many were removed.
+func (list *SkipList[K, V]) DeleteRange(start, end K) int {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	if list.compare(start, end) >= 0 {
+		return 0
+	}
+
+	startHistory := make([]*SkipListNode[K, V], list.maxLevel)
+	startRanks := make([]int, list.maxLevel)
+	first := list.findPathInternal(start, startHistory, startRanks)
+
+	endHistory := make([]*SkipListNode[K, V], list.maxLevel)
+	endRanks := make([]int, list.maxLevel)
+	last := list.findPathInternal(end, endHistory, endRanks)
+
+	removed := endRanks[0] - startRanks[0]
+	if removed == 0 {
+		return 0
+	}
+
+	for node := first; node != last; node = node.nextNode[0] {
+		list.size -= list.entrySize(node.item.key, node.item.value)
+		list.forget(node)
+		list.retire(node)
+		list.lastAccess.CompareAndSwap(node, nil)
+	}
+
+	for i := 0; i < list.maxLevel; i++ {
+		prev := startHistory[i]
+		if endHistory[i] == prev {
+			prev.span[i] -= removed
+			continue
+		}
+
+		next := endHistory[i].nextNode[i]
+		distance := endRanks[i] + endHistory[i].span[i] - startRanks[i]
+
+		prev.nextNode[i] = next
+		next.prevNode[i] = prev
+		prev.span[i] = distance - removed
+	}
+
+	list.length -= removed
+	return removed
+}
+
+// DeleteWhile removes keys >= start in order for as long as fn returns true,
+// and returns how many were removed.
+func (list *SkipList[K, V]) DeleteWhile(start K, fn func(key K) bool) int {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	removed := 0
+	for node := list.findGreaterOrEqual(start, nil); !node.isEndNode; {
+		if !fn(node.item.key) {
+			break
+		}
+
+		next := node.nextNode[0]
+		list.deleteNode(node)
+		removed++
+		node = next
+	}
+	return removed
+}
+
+// Trim removes the largest keys until at most maxEntries remain and returns
+// the number of keys removed.
+func (list *SkipList[K, V]) Trim(maxEntries int) int {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	removed := 0
+	for list.length > max(maxEntries, 0) {
+		list.deleteNode(list.tail.prevNode[0])
+		removed++
+	}
+	return removed
+}
+
+// Clear removes every entry. It only relinks the head to the tail and leaves
+// the removed nodes untouched, so an iterator standing on one of them still
+// walks forward to the tail and then stops.
+func (list *SkipList[K, V]) Clear() {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	list.retireAll()
+	list.lastAccess.Store(nil)
+	for i := 0; i < list.maxLevel; i++ {
+		list.head.nextNode[i] = list.tail
+		list.head.span[i] = 1
+		list.tail.prevNode[i] = list.head
+	}
+
+	list.length = 0
+	list.size = 0
+	list.maxKeyLen = 0
+	list.maxValueLen = 0
+	list.resetRecency()
+}
+
+// Reset replaces the contents of the list with items under one write lock,
+// after changing the max level to maxLevel. Values below 1 are raised to 1.
+// Unlike Clear it links fresh end nodes, and the items are set one by one, so
+// later duplicates win unless the list is a multiset.
+func (list *SkipList[K, V]) Reset(maxLevel int, items []SkipListItem[K, V]) {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	list.maxLevel = max(maxLevel, minLevel)
+	list.retireAll()
+	list.lastAccess.Store(nil)
+	list.head, list.tail = newEndNodes[K, V](list.maxLevel)
+	list.length = 0
+	list.size = 0
+	list.maxKeyLen = 0
+	list.maxValueLen = 0
+	list.resetRecency()
+
+	for _, item := range items {
+		list.setInternal(item.key, item.value)
+	}
+}
+
+// Resize changes the maximum node height. Growing adds empty levels on top;
+// shrinking caps taller nodes at the new height. Values below 1 are raised to
+// 1. A Growable list is not shrunk below the height its length needs, since
+// it would grow back on the next insert.
+func (list *SkipList[K, V]) Resize(newMaxLevel int) {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	newMaxLevel = max(newMaxLevel, minLevel)
+	if list.growable && list.length > 1 {
+		newMaxLevel = max(newMaxLevel, bits.Len(uint(list.length-1)))
+	}
+	if newMaxLevel > list.maxLevel {
+		list.growInternal(newMaxLevel)
+	} else if newMaxLevel < list.maxLevel {
+		list.shrinkInternal(newMaxLevel)
+	}
+}
+
+// Compact rebuilds the list with freshly drawn node heights, capped to what
+// the current length needs. It keeps keys, values, expiry and recency.
+func (list *SkipList[K, V]) Compact() {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	nodes := make([]*SkipListNode[K, V], 0, list.length)
+	for node := list.head.nextNode[0]; !node.isEndNode; node = node.nextNode[0] {
+		nodes = append(nodes, node)
+	}
+
+	list.head, list.tail = newEndNodes[K, V](list.maxLevel)
+	list.length = 0
+	list.size = 0
+	list.maxKeyLen = 0
+	list.maxValueLen = 0
+
+	levelCap := clamp(bits.Len(uint(len(nodes))), minLevel, list.maxLevel)
+	history := make([]*SkipListNode[K, V], list.maxLevel)
+	for _, node := range nodes {
+		// linkNode may grow a growable list partway through the rebuild
+		for len(history) < list.maxLevel {
+			history = append(history, nil)
+		}
+
+		for i := range history {
+			history[i] = list.tail.prevNode[i]
+		}
+		list.linkNode(node, list.randomLevelUpTo(levelCap), history)
+	}
+}
+
+func (list *SkipList[K, V]) Merge(other *SkipList[K, V]) {
+	items := other.snapshot()
+
+	list.mutex.Lock()
+	defer list.unlock()
+
+	for _, item := range items {
+		list.setInternal(item.key, item.value)
+	}
+}
+
+// EqualFunc reports whether both lists hold equal keys in the same order with
+// values that equal reports as equal. Node heights and random sources are
+// ignored.
+func (list *SkipList[K, V]) EqualFunc(other *SkipList[K, V], equal func(a, b V) bool) bool {
+	if list == other {
+		return true
+	}
+
+	items := other.snapshot()
+
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	if list.length != len(items) {
+		return false
+	}
+
+	node := list.head.nextNode[0]
+	for _, item := range items {
+		if !node.match(item.key, list.compare) || !equal(node.item.value, item.value) {
+			return false
+		}
+		node = node.nextNode[0]
+	}
+	return true
+}
+
+func (list *SkipList[K, V]) Clone() *SkipList[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.copyInternal(list.head.nextNode[0], list.tail)
+}
+
+// CopyRange returns an independent list holding copies of the entries in
+// [start, end), configured like this one.
+func (list *SkipList[K, V]) CopyRange(start, end K) *SkipList[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	first := list.findGreaterOrEqual(start, nil)
+	last := first
+	if list.compare(start, end) < 0 {
+		last = list.findGreaterOrEqual(end, nil)
+	}
+	return list.copyInternal(first, last)
+}
+
+// copyInternal builds a list with the same settings holding copies of the
+// nodes from first up to, but not including, last.
+func (list *SkipList[K, V]) copyInternal(first, last *SkipListNode[K, V]) *SkipList[K, V] {
+	clone := list.emptyCopy()
+	for node := first; node != last; node = node.nextNode[0] {
+		clone.appendInternal(node.item.key, node.item.value).expireAt = node.expireAt
+	}
+
+	clone.storeFn = list.storeFn
+	return clone
+}
+
+// emptyCopy returns an empty list with the same settings. Values appended to
+// it go through CopyValue; the caller restores storeFn once it is filled.
+func (list *SkipList[K, V]) emptyCopy() *SkipList[K, V] {
+	clone := NewWithComparator[K, V](list.maxLevel, list.compare)
+	clone.promote = list.promote
+	clone.levelFunc = list.levelFunc
+	clone.cacheLast = list.cacheLast
+	if list.nodePool != nil {
+		clone.nodePool = newNodePool()
+	}
+	clone.growable = list.growable
+	clone.multiset = list.multiset
+	if list.recency != nil {
+		clone.maxEntries = list.maxEntries
+		clone.recency = &SkipListNode[K, V]{}
+		clone.resetRecency()
+	}
+	clone.maxBytes = list.maxBytes
+	clone.now = list.now
+	clone.keySize = list.keySize
+	clone.valueSize = list.valueSize
+	clone.copyFn = list.copyFn
+	clone.storeFn = list.copyFn
+	return clone
+}
+
+// setInternal is the write path shared by Set and its variants, and counts
+// the entry it writes.
+func (list *SkipList[K, V]) setInternal(key K, value V) (V, bool) {
+	var zero V
+
+	list.counters.sets.Add(1)
+	if list.multiset {
+		list.insertLastInternal(key, value)
+		return zero, false
+	}
+
+	history := make([]*SkipListNode[K, V], list.maxLevel)
+	node := list.findInternal(key, history)
+	if node != nil && !list.expired(node) {
+		return list.updateNode(node, value), true
+	}
+
+	if node != nil {
+		list.updateNode(node, value)
+		return zero, false
+	}
+
+	list.insertNode(key, value, history)
+	return zero, false
+}
+
+// insertLastInternal inserts key after every node with an equal key, so
+// duplicates keep their insertion order.
+func (list *SkipList[K, V]) insertLastInternal(key K, value V) *SkipListNode[K, V] {
+	return list.insertLastAt(key, value, list.randomLevel())
+}
+
+func (list *SkipList[K, V]) insertLastAt(key K, value V, level int) *SkipListNode[K, V] {
+	history := make([]*SkipListNode[K, V], list.maxLevel)
+
+	current := list.head
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		for current.next(i) != nil && list.tail != current.next(i) && list.compare(current.next(i).item.key, key) <= 0 {
+			current = current.next(i)
+		}
+		history[i] = current
+	}
+	return list.insertNodeAt(key, value, level, history)
+}
+
+func (list *SkipList[K, V]) findInternal(key K, history []*SkipListNode[K, V]) *SkipListNode[K, V] {
+	current := list.findGreaterOrEqual(key, history)
+	if current.isEndNode || !current.match(key, list.compare) {
+		return nil
+	}
+	return current
+}
+
+func (list *SkipList[K, V]) findGreaterOrEqual(key K, history []*SkipListNode[K, V]) *SkipListNode[K, V] {
+	current := list.head
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		for list.before(current.next(i), key) {
+			current = current.next(i)
+		}
+
+		if history != nil {
+			history[i] = current
+		}
+	}
+	return current.next(0)
+}
+
+// before reports whether a search for key should advance to node. A nil node,
+// left by a neighbour shorter than the level being searched, stops the level
+// instead of being dereferenced.
+func (list *SkipList[K, V]) before(node *SkipListNode[K, V], key K) bool {
+	return node != nil && node != list.tail && list.compare(node.item.key, key) < 0
+}
+
+// findFromInternal works like findInternal, but resumes each level from the
+// previous search path in history when it is ahead of the current position.
+// The keys searched must be non-decreasing between calls.
+func (list *SkipList[K, V]) findFromInternal(key K, history []*SkipListNode[K, V]) *SkipListNode[K, V] {
+	current := list.head
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		if history[i] != list.head && (current == list.head || list.compare(current.item.key, history[i].item.key) < 0) {
+			current = history[i]
+		}
+
+		for list.before(current.next(i), key) {
+			current = current.next(i)
+		}
+		history[i] = current
+	}
+
+	current = current.next(0)
+	if current.isEndNode || !current.match(key, list.compare) {
+		return nil
+	}
+	return current
+}
+
+func (list *SkipList[K, V]) findLessOrEqual(key K) *SkipListNode[K, V] {
+	node := list.findGreaterOrEqual(key, nil)
+	if !node.isEndNode && node.match(key, list.compare) {
+		return node
+	}
+	return node.prevNode[0]
+}
+
+// rankInternal returns the number of keys less than key and the first node
+// with key >= key.
+func (list *SkipList[K, V]) rankInternal(key K) (int, *SkipListNode[K, V]) {
+	rank := 0
+	current := list.head
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		for list.before(current.next(i), key) {
+			rank += current.span[i]
+			current = current.next(i)
+		}
+	}
+	return rank, current.next(0)
+}
+
+// findPathInternal fills history with the last node before key on each level
+// and ranks with the position of those nodes, and returns the first node with
+// key >= key.
+func (list *SkipList[K, V]) findPathInternal(key K, history []*SkipListNode[K, V], ranks []int) *SkipListNode[K, V] {
+	rank := 0
+	current := list.head
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		for list.before(current.next(i), key) {
+			rank += current.span[i]
+			current = current.next(i)
+		}
+
+		history[i] = current
+		ranks[i] = rank
+	}
+	return current.next(0)
+}
+
+func (list *SkipList[K, V]) selectInternal(n int) *SkipListNode[K, V] {
+	target := n + 1
+
+	traversed := 0
+	current := list.head
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		for current.next(i) != nil && list.tail != current.next(i) && traversed+current.span[i] <= target {
+			traversed += current.span[i]
+			current = current.next(i)
+		}
+
+		if traversed == target {
+			return current
+		}
+	}
+	return nil
+}
+
+func (list *SkipList[K, V]) appendInternal(key K, value V) *SkipListNode[K, V] {
+	history := make([]*SkipListNode[K, V], list.maxLevel)
+	for i := range history {
+		history[i] = list.tail.prevNode[i]
+	}
+	return list.insertNode(key, value, history)
+}
+
+func (list *SkipList[K, V]) popInternal(node *SkipListNode[K, V]) (*SkipListItem[K, V], bool) {
+	if node.isEndNode {
+		return nil, false
+	}
+
+	item := node.item
+	list.deleteNode(node)
+	return &item, true
+}
+
+func (list *SkipList[K, V]) updateNode(node *SkipListNode[K, V], value V) V {
+	node.expireAt = time.Time{}
+	return list.replaceValue(node, value)
+}
+
+// replaceValue swaps the value of node while keeping its expiry.
+func (list *SkipList[K, V]) replaceValue(node *SkipListNode[K, V], value V) V {
+	old := node.item.value
+	node.item.value = list.storeValue(value)
+	list.resizeValue(old, node.item.value)
+	list.touch(node)
+	list.evictInternal()
+	return old
+}
+
+// resizeValue updates the size counters for a value replaced by another.
+func (list *SkipList[K, V]) resizeValue(old, value V) {
+	list.size -= uint64(list.valueLen(old))
+	list.size += uint64(list.valueLen(value))
+	list.maxValueLen = max(list.maxValueLen, list.valueLen(value))
+}
+
+func (list *SkipList[K, V]) insertNode(key K, value V, history []*SkipListNode[K, V]) *SkipListNode[K, V] {
+	return list.insertNodeAt(key, value, list.randomLevel(), history)
+}
+
+func (list *SkipList[K, V]) insertNodeAt(key K, value V, level int, history []*SkipListNode[K, V]) *SkipListNode[K, V] {
+	node := list.newNode()
+	node.item = SkipListItem[K, V]{key: key, value: list.storeValue(value)}
+
+	list.linkNode(node, level, history)
+	list.touch(node)
+	list.evictInternal()
+	return node
+}
+
+// linkNode gives node the height level and links it after the search path in
+// history, keeping the spans and the length and size counters up to date.
+func (list *SkipList[K, V]) linkNode(node *SkipListNode[K, V], level int, history []*SkipListNode[K, V]) {
+	node.levels = level
+	node.prevNode = withLen(node.prevNode, level)
+	node.nextNode = withLen(node.nextNode, level)
+	node.span = withLen(node.span, level)
+
+	// distance is the number of level 0 hops from history[i] to history[0]
+	distance := 0
+	for i := 0; i < list.maxLevel; i++ {
+		if i > 0 {
+			for current := history[i]; current != history[i-1]; current = current.nextNode[i-1] {
+				distance += current.span[i-1]
+			}
+		}
+
+		if i >= level {
+			history[i].span[i]++
+			continue
+		}
+
+		history[i].appendOnLevel(node, i)
+		node.span[i] = history[i].span[i] - distance
+		history[i].span[i] = distance + 1
+	}
+
+	list.length++
+	list.size += list.entrySize(node.item.key, node.item.value)
+	list.maxKeyLen = max(list.maxKeyLen, list.keyLen(node.item.key))
+	list.maxValueLen = max(list.maxValueLen, list.valueLen(node.item.value))
+
+	if list.growable && list.length > 1<<list.maxLevel {
+		list.growInternal(list.maxLevel + 1)
+	}
+}
+
+// shrinkInternal lowers maxLevel, capping every taller node to the new height.
+func (list *SkipList[K, V]) shrinkInternal(newMaxLevel int) {
+	for node := list.head; node != nil; {
+		next := node.nextNode[newMaxLevel]
+		clear(node.prevNode[newMaxLevel:])
+		clear(node.nextNode[newMaxLevel:])
+		node.prevNode = node.prevNode[:newMaxLevel]
+		node.nextNode = node.nextNode[:newMaxLevel]
+		node.span = node.span[:newMaxLevel]
+		node.levels = newMaxLevel
+		node = next
+	}
+
+	list.maxLevel = newMaxLevel
+}
+
+// growInternal raises maxLevel, linking head to tail on every new level.
+func (list *SkipList[K, V]) growInternal(newMaxLevel int) {
+	for i := list.maxLevel; i < newMaxLevel; i++ {
+		list.head.prevNode = append(list.head.prevNode, nil)
+		list.head.nextNode = append(list.head.nextNode, list.tail)
+		list.head.span = append(list.head.span, list.length+1)
+
+		list.tail.prevNode = append(list.tail.prevNode, list.head)
+		list.tail.nextNode = append(list.tail.nextNode, nil)
+		list.tail.span = append(list.tail.span, 0)
+	}
+
+	list.head.levels = newMaxLevel
+	list.tail.levels = newMaxLevel
+	list.maxLevel = newMaxLevel
+}
+
+func (list *SkipList[K, V]) deleteNode(node *SkipListNode[K, V]) {
+	list.forget(node)
+	list.retire(node)
+	list.lastAccess.CompareAndSwap(node, nil)
+	list.unlinkNode(node)
+	list.recycle(node)
+}
+
+// unlinkNode takes node out of every level, keeping the spans and the length
+// and size counters up to date. The node's own links are left as they are.
+func (list *SkipList[K, V]) unlinkNode(node *SkipListNode[K, V]) {
+	list.size -= list.entrySize(node.item.key, node.item.value)
+
+	for i := 0; i < node.nodeLevel(); i++ {
+		node.prevNode[i].span[i] += node.span[i] - 1
+		node.removeOnLevel(i)
+	}
+
+	covering := node.prevNode[node.nodeLevel()-1]
+	for i := node.nodeLevel(); i < list.maxLevel; i++ {
+		for covering.nodeLevel() <= i {
+			covering = covering.prevNode[covering.nodeLevel()-1]
+		}
+		covering.span[i]--
+	}
+
+	list.length--
+}
+
+func (list *SkipList[K, V]) randomLevel() int {
+	return list.randomLevelUpTo(list.maxLevel)
+}
+
+func (list *SkipList[K, V]) randomLevelUpTo(maxLevel int) int {
+	if list.levelFunc != nil {
+		return clamp(list.levelFunc(), minLevel, maxLevel)
+	}
+
+	prob := list.promote
+	rand := list.rand
+
+	level := 1
+	for ; (level < maxLevel) && (rand.Int31() > prob); level++ {
+	}
+
+	return level
+}
+
+func (list *SkipList[K, V]) nodeMemoryBytes(node *SkipListNode[K, V]) uint64 {
+	perLevel := 2*unsafe.Sizeof(node) + unsafe.Sizeof(int(0))
+	return uint64(unsafe.Sizeof(*node)) +
+		uint64(node.levels)*uint64(perLevel) +
+		list.entrySize(node.item.key, node.item.value)
+}
+
+func (list *SkipList[K, V]) keyLen(key K) int {
+	if list.keySize == nil {
+		return 0
+	}
+	return list.keySize(key)
+}
+
+func (list *SkipList[K, V]) valueLen(value V) int {
+	if list.valueSize == nil {
+		return 0
+	}
+	return list.valueSize(value)
+}
+
+// entrySize is what an entry adds to Size.
+func (list *SkipList[K, V]) entrySize(key K, value V) uint64 {
+	return uint64(list.keyLen(key)) + uint64(list.valueLen(value))
+}
+
+// copyValue returns the copy of a stored value that reads hand out.
+func (list *SkipList[K, V]) copyValue(value V) V {
+	if list.copyFn == nil {
+		return value
+	}
+	return list.copyFn(value)
+}
+
+// storeValue returns what to keep for a value passed to a write.
+func (list *SkipList[K, V]) storeValue(value V) V {
+	if list.storeFn == nil {
+		return value
+	}
+	return list.storeFn(value)
+}
+
+func clamp(value, low, high int) int {
+	return max(low, min(value, high))
+}
@@ -0,0 +1,462 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generic
+
+import (
+	"bytes"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testStruct struct {
+	name  string
+	count int
+}
+
+func TestNew(t *testing.T) {
+	list := New[int, testStruct](16)
+	if assert.NotNil(t, list) {
+		assert.Equal(t, list.MaxLevel(), 16)
+		assert.Equal(t, list.Length(), 0)
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	list := New[int, int](5)
+	assert.Nil(t, list.Front())
+	assert.Nil(t, list.Back())
+	assert.Nil(t, list.Get(1))
+	assert.False(t, list.Remove(1))
+
+	list.Set(1, 1)
+	assert.True(t, list.Remove(1))
+	assert.Nil(t, list.Front())
+	assert.Nil(t, list.Back())
+	assert.Equal(t, list.Length(), 0)
+}
+
+func TestInvalidMaxLevel(t *testing.T) {
+	list := New[int, int](0)
+	if assert.NotNil(t, list) {
+		assert.Equal(t, list.MaxLevel(), 1)
+
+		list.Set(1, 1)
+		assert.Equal(t, list.Get(1).Value(), 1)
+	}
+}
+
+func TestIntKey(t *testing.T) {
+	list := New[int, testStruct](5)
+
+	keys := rand.Perm(100)
+	for _, key := range keys {
+		list.Set(key, testStruct{name: strconv.Itoa(key), count: key})
+	}
+
+	assert.Equal(t, list.Length(), 100)
+
+	item := list.Get(42)
+	if assert.NotNil(t, item) {
+		assert.Equal(t, item.Key(), 42)
+		assert.Equal(t, item.Value(), testStruct{name: "42", count: 42})
+	}
+
+	assert.Equal(t, list.Get(100), (*SkipListItem[int, testStruct])(nil))
+
+	expected := 0
+	for node := list.Front(); node != nil; node = node.Next() {
+		assert.Equal(t, node.Key(), expected)
+		expected++
+	}
+	assert.Equal(t, expected, 100)
+}
+
+func TestFloatKey(t *testing.T) {
+	list := New[float64, int](5)
+
+	var keys []float64
+	for i := 0; i < 50; i++ {
+		key := rand.Float64()
+		list.Set(key, i)
+		keys = append(keys, key)
+	}
+
+	sort.Float64s(keys)
+
+	node := list.Back()
+	for i := range keys {
+		key := keys[len(keys)-1-i]
+		if assert.NotNil(t, node) {
+			assert.Equal(t, node.Key(), key)
+		}
+		node = node.Prev()
+	}
+}
+
+func TestStringKey(t *testing.T) {
+	list := New[string, []byte](5)
+
+	for i := 0; i < 10; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	list.Set("1", []byte("11"))
+	item := list.Get("1")
+	if assert.NotNil(t, item) {
+		assert.Equal(t, item.Value(), []byte("11"))
+	}
+
+	list.Remove("1")
+	assert.Equal(t, list.Get("1"), (*SkipListItem[string, []byte])(nil))
+	assert.Equal(t, list.Length(), 9)
+}
+
+type tenantKey struct {
+	tenant string
+	id     int
+}
+
+func (key tenantKey) Less(other tenantKey) bool {
+	if key.tenant != other.tenant {
+		return key.tenant < other.tenant
+	}
+	return key.id < other.id
+}
+
+func TestLessKey(t *testing.T) {
+	list := NewWithLess[tenantKey, string](5)
+
+	var keys []tenantKey
+	for _, tenant := range []string{"acme", "globex", "initech"} {
+		for id := 0; id < 10; id++ {
+			keys = append(keys, tenantKey{tenant: tenant, id: id})
+		}
+	}
+
+	shuffled := make([]tenantKey, len(keys))
+	copy(shuffled, keys)
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	for _, key := range shuffled {
+		list.Set(key, key.tenant+"/"+strconv.Itoa(key.id))
+	}
+	assert.Equal(t, list.Length(), len(keys))
+
+	var ordered []tenantKey
+	for node := list.Front(); node != nil; node = node.Next() {
+		ordered = append(ordered, node.Key())
+	}
+	assert.Equal(t, ordered, keys)
+
+	list.Set(tenantKey{tenant: "globex", id: 3}, "updated")
+	assert.Equal(t, list.Length(), len(keys))
+	assert.Equal(t, list.Get(tenantKey{tenant: "globex", id: 3}).Value(), "updated")
+	assert.Nil(t, list.Get(tenantKey{tenant: "globex", id: 10}))
+
+	list.Remove(tenantKey{tenant: "acme", id: 0})
+	assert.Equal(t, list.Front().Key(), tenantKey{tenant: "acme", id: 1})
+	assert.Equal(t, list.Back().Key(), tenantKey{tenant: "initech", id: 9})
+}
+
+func TestOverLevelOnNode(t *testing.T) {
+	fistNode := &SkipListNode[string, []byte]{
+		levels:    5,
+		prevNode:  make([]*SkipListNode[string, []byte], 5),
+		nextNode:  make([]*SkipListNode[string, []byte], 5),
+		item:      SkipListItem[string, []byte]{key: "1", value: []byte("1")},
+		isEndNode: false,
+	}
+
+	secondNode := &SkipListNode[string, []byte]{
+		levels:    3,
+		prevNode:  make([]*SkipListNode[string, []byte], 3),
+		nextNode:  make([]*SkipListNode[string, []byte], 3),
+		item:      SkipListItem[string, []byte]{key: "2", value: []byte("2")},
+		isEndNode: false,
+	}
+
+	fistNode.appendOnLevel(secondNode, 0)
+	fistNode.appendOnLevel(secondNode, 1)
+	fistNode.appendOnLevel(secondNode, 2)
+
+	temp := fistNode.next(0)
+	assert.Equal(t, temp, secondNode)
+
+	temp = fistNode.next(1)
+	assert.Equal(t, temp, secondNode)
+
+	temp = fistNode.next(2)
+	assert.Equal(t, temp, secondNode)
+
+	temp = fistNode.next(3)
+	assert.Equal(t, temp, (*SkipListNode[string, []byte])(nil))
+
+	temp = fistNode.next(6)
+	assert.Equal(t, temp, (*SkipListNode[string, []byte])(nil))
+}
+
+func TestNextOnLevel(t *testing.T) {
+	testCases := []struct {
+		levels int
+	}{
+		{levels: 1},
+		{levels: 3},
+		{levels: 5},
+	}
+
+	for _, testCase := range testCases {
+		next := &SkipListNode[string, []byte]{
+			levels:   testCase.levels,
+			prevNode: make([]*SkipListNode[string, []byte], testCase.levels),
+			nextNode: make([]*SkipListNode[string, []byte], testCase.levels),
+		}
+
+		node := &SkipListNode[string, []byte]{
+			levels:   testCase.levels,
+			prevNode: make([]*SkipListNode[string, []byte], testCase.levels),
+			nextNode: make([]*SkipListNode[string, []byte], testCase.levels),
+		}
+
+		for i := 0; i < testCase.levels; i++ {
+			node.appendOnLevel(next, i)
+		}
+
+		for i := 0; i <= testCase.levels+2; i++ {
+			if i < testCase.levels {
+				assert.Equal(t, node.next(i), next)
+			} else {
+				assert.Equal(t, node.next(i), (*SkipListNode[string, []byte])(nil))
+			}
+		}
+	}
+}
+
+func TestSearchRaggedHeights(t *testing.T) {
+	list, err := NewWithOptions(Options[string, []byte]{MaxLevel: 4, Comparator: strings.Compare, LevelFunc: func() int { return 1 }})
+	assert.Nil(t, err)
+	for _, key := range []string{"a", "c", "e"} {
+		list.Set(key, []byte(key))
+	}
+
+	// head reaches "a" on a level that "a" does not have
+	short := list.Front()
+	list.head.nextNode[3] = short
+	assert.Equal(t, short.nodeLevel(), 1)
+
+	assert.NotPanics(t, func() {
+		assert.Equal(t, list.Get("c").Value(), []byte("c"))
+		assert.Equal(t, list.Get("e").Value(), []byte("e"))
+		assert.Nil(t, list.Get("d"))
+		assert.True(t, list.Contains("a"))
+		assert.Equal(t, list.Ceil("b").Key(), "c")
+		assert.Equal(t, list.Floor("d").Key(), "c")
+	})
+}
+
+func TestCompactGrowsDuringRebuild(t *testing.T) {
+	list, _ := NewWithOptions(Options[string, []byte]{MaxLevel: 1, Comparator: strings.Compare, Growable: true})
+	for i := 0; i < 5; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	// leave more entries than the max level allows so the rebuild grows it
+	list.shrinkInternal(1)
+	list.Compact()
+	assert.Equal(t, list.MaxLevel(), 3)
+	assert.Equal(t, list.Keys(), []string{"0", "1", "2", "3", "4"})
+	assert.Nil(t, list.Validate())
+}
+
+func TestSetWithLevelMultiset(t *testing.T) {
+	list, err := NewWithOptions(Options[string, int]{MaxLevel: 4, Comparator: strings.Compare, Multiset: true})
+	assert.Nil(t, err)
+
+	assert.Nil(t, list.SetWithLevel("a", 1, 3))
+	assert.Nil(t, list.SetWithLevel("a", 2, 1))
+	assert.Equal(t, list.Values(), []int{1, 2})
+
+	var levels []int
+	for node := list.Front(); node != nil; node = node.Next() {
+		levels = append(levels, node.nodeLevel())
+	}
+	assert.Equal(t, levels, []int{3, 1})
+	assert.Nil(t, list.Validate())
+}
+
+func TestKeysPage(t *testing.T) {
+	list := New[int, int](5)
+	for i := 0; i < 5; i++ {
+		list.Set(i, i)
+	}
+
+	// a nil after starts at the zero key, which a zero after would skip
+	assert.Equal(t, list.KeysPage(nil, 2), []int{0, 1})
+
+	after := 1
+	assert.Equal(t, list.KeysPage(&after, 2), []int{2, 3})
+
+	after = 3
+	assert.Equal(t, list.KeysPage(&after, 2), []int{4})
+}
+
+func TestCountFrom(t *testing.T) {
+	list := New[int, int](5)
+	for i := 0; i < 10; i += 2 {
+		list.Set(i, i)
+	}
+
+	assert.Equal(t, list.CountFrom(-1), 5)
+	assert.Equal(t, list.CountFrom(3), 3)
+	assert.Equal(t, list.CountFrom(8), 1)
+	assert.Equal(t, list.CountFrom(9), 0)
+	assert.Equal(t, list.CountRange(3, 8), 2)
+}
+
+func TestRangeFrom(t *testing.T) {
+	list := New[int, int](5)
+	for i := 0; i < 10; i++ {
+		list.Set(i, i)
+	}
+
+	var keys []int
+	list.RangeFrom(6, func(node *SkipListNode[int, int]) bool {
+		keys = append(keys, node.Key())
+		return true
+	})
+	assert.Equal(t, keys, []int{6, 7, 8, 9})
+
+	keys = nil
+	list.RangeFrom(2, func(node *SkipListNode[int, int]) bool {
+		keys = append(keys, node.Key())
+		return node.Key() < 4
+	})
+	assert.Equal(t, keys, []int{2, 3, 4})
+}
+
+func TestDeleteWhile(t *testing.T) {
+	list := New[int, int](5)
+	for i := 0; i < 10; i++ {
+		list.Set(i, i)
+	}
+
+	assert.Equal(t, list.DeleteWhile(3, func(key int) bool { return key < 6 }), 3)
+	assert.Equal(t, list.Keys(), []int{0, 1, 2, 6, 7, 8, 9})
+	assert.Equal(t, list.DeleteWhile(20, func(key int) bool { return true }), 0)
+	assert.Nil(t, list.Validate())
+}
+
+func TestModify(t *testing.T) {
+	list, err := NewWithOptions(Options[string, []int]{
+		MaxLevel:   5,
+		Comparator: strings.Compare,
+		ValueSize:  func(value []int) int { return len(value) },
+		StoreValue: func(value []int) []int { return append([]int(nil), value...) },
+	})
+	assert.Nil(t, err)
+
+	list.Set("a", []int{1})
+	assert.True(t, list.Modify("a", func(value []int) []int {
+		return append(value, 2, 3)
+	}))
+	assert.False(t, list.Modify("b", func(value []int) []int { return value }))
+
+	assert.Equal(t, list.Get("a").Value(), []int{1, 2, 3})
+	assert.Equal(t, list.Size(), uint64(3))
+	assert.Equal(t, list.MaxValueLen(), 3)
+	assert.Nil(t, list.Validate())
+}
+
+func TestCompareAndSwapFunc(t *testing.T) {
+	list := New[string, []byte](5)
+	list.Set("a", []byte("1"))
+
+	assert.False(t, list.CompareAndSwapFunc("a", []byte("2"), []byte("3"), bytes.Equal))
+	assert.True(t, list.CompareAndSwapFunc("a", []byte("1"), []byte("3"), bytes.Equal))
+	assert.False(t, list.CompareAndSwapFunc("b", nil, []byte("3"), bytes.Equal))
+	assert.Equal(t, list.Get("a").Value(), []byte("3"))
+}
+
+func TestEqualFunc(t *testing.T) {
+	first := New[int, []byte](5)
+	second := New[int, []byte](8)
+	for i := 0; i < 10; i++ {
+		first.Set(i, []byte(strconv.Itoa(i)))
+		second.Set(i, []byte(strconv.Itoa(i)))
+	}
+	assert.True(t, first.EqualFunc(second, bytes.Equal))
+	assert.True(t, first.EqualFunc(first, bytes.Equal))
+
+	second.Set(3, []byte("x"))
+	assert.False(t, first.EqualFunc(second, bytes.Equal))
+
+	second.Set(3, []byte("3"))
+	second.Set(10, []byte("10"))
+	assert.False(t, first.EqualFunc(second, bytes.Equal))
+}
+
+func TestSetWithKeyCopy(t *testing.T) {
+	list := New[string, int](5)
+	copies := 0
+	copyKey := func(key string) string {
+		copies++
+		return strings.Clone(key)
+	}
+
+	list.SetWithKeyCopy("a", 1, copyKey)
+	list.SetWithKeyCopy("a", 2, copyKey)
+	list.SetWithKeyCopy("b", 3, copyKey)
+	assert.Equal(t, copies, 2)
+	assert.Equal(t, list.Get("a").Value(), 2)
+	assert.Equal(t, list.Stats().Sets, uint64(3))
+}
+
+func TestReset(t *testing.T) {
+	list := New[int, int](3)
+	for i := 0; i < 10; i++ {
+		list.Set(i, i)
+	}
+
+	removed := 0
+	list.OnRemove(func(key int, value int) { removed++ })
+	list.Reset(6, []SkipListItem[int, int]{NewItem(2, 2), NewItem(1, 1), NewItem(2, 3)})
+
+	assert.Equal(t, list.MaxLevel(), 6)
+	assert.Equal(t, list.Keys(), []int{1, 2})
+	assert.Equal(t, list.Get(2).Value(), 3)
+	assert.Equal(t, removed, 10)
+	assert.Nil(t, list.Validate())
+
+	list.Reset(0, nil)
+	assert.Equal(t, list.MaxLevel(), 1)
+	assert.Equal(t, list.Length(), 0)
+}
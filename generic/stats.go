@@ -0,0 +1,98 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generic
+
+import "sync/atomic"
+
+// searchSamples caps the number of keys AvgSearchLength looks up.
+const searchSamples = 1024
+
+// Stats counts calls since the list was created. Sets counts every entry
+// written by Set and its variants, batch writes, Merge, Apply and Reset, once
+// per entry; GetOrSet and SetIfAbsent count only when they write.
+// ReplaceValue, CompareAndSwapFunc, Modify and Swap change existing values
+// and are not counted. Gets counts lookups, split into Hits and Misses, and
+// Removes counts Remove calls.
+type Stats struct {
+	Sets    uint64
+	Gets    uint64
+	Hits    uint64
+	Misses  uint64
+	Removes uint64
+}
+
+type counters struct {
+	sets    atomic.Uint64
+	gets    atomic.Uint64
+	hits    atomic.Uint64
+	misses  atomic.Uint64
+	removes atomic.Uint64
+}
+
+func (list *SkipList[K, V]) Stats() Stats {
+	return Stats{
+		Sets:    list.counters.sets.Load(),
+		Gets:    list.counters.gets.Load(),
+		Hits:    list.counters.hits.Load(),
+		Misses:  list.counters.misses.Load(),
+		Removes: list.counters.removes.Load(),
+	}
+}
+
+// AvgSearchLength returns the average number of forward hops a lookup of a
+// stored key takes. Up to searchSamples keys spread evenly over the list are
+// looked up, so the result is exact for small lists. A value far above
+// log2(Length()) suggests raising the max level. It returns 0 for an empty
+// list.
+func (list *SkipList[K, V]) AvgSearchLength() float64 {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	if list.length == 0 {
+		return 0
+	}
+
+	stride := (list.length + searchSamples - 1) / searchSamples
+	total, samples := 0, 0
+	position := 0
+	for node := list.head.nextNode[0]; !node.isEndNode; node = node.nextNode[0] {
+		if position%stride == 0 {
+			_, steps := list.searchSteps(node.item.key)
+			total += steps
+			samples++
+		}
+		position++
+	}
+	return float64(total) / float64(samples)
+}
+
+func (counters *counters) recordGet(hit bool) {
+	counters.gets.Add(1)
+	if hit {
+		counters.hits.Add(1)
+	} else {
+		counters.misses.Add(1)
+	}
+}
@@ -0,0 +1,171 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generic
+
+import "time"
+
+func (list *SkipList[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	list.counters.sets.Add(1)
+
+	list.mutex.Lock()
+	defer list.unlock()
+
+	history := make([]*SkipListNode[K, V], list.maxLevel)
+	node := list.findInternal(key, history)
+	if list.multiset {
+		node = list.insertLastInternal(key, value)
+	} else if node != nil {
+		list.updateNode(node, value)
+	} else {
+		node = list.insertNode(key, value, history)
+	}
+
+	node.expireAt = list.now().Add(ttl)
+}
+
+// GC removes every expired entry and returns how many were removed.
+func (list *SkipList[K, V]) GC() int {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	removed := 0
+	for node := list.head.nextNode[0]; !node.isEndNode; {
+		next := node.nextNode[0]
+		if list.expired(node) {
+			list.deleteNode(node)
+			removed++
+		}
+		node = next
+	}
+	return removed
+}
+
+// EnableExpiry starts a goroutine that calls GC every interval, so expired
+// entries are removed even if they are never read again. Removed entries are
+// reported to the OnRemove hook. Calling it again restarts the sweeper with
+// the new interval; a non-positive interval only stops it. The sweeper keeps
+// the list alive, so call StopExpiry once the list is no longer needed.
+func (list *SkipList[K, V]) EnableExpiry(interval time.Duration) {
+	list.expiryMutex.Lock()
+	defer list.expiryMutex.Unlock()
+
+	list.stopExpiryInternal()
+	if interval <= 0 {
+		return
+	}
+
+	list.expiryStop = make(chan struct{})
+	list.expiryDone = make(chan struct{})
+	go list.sweep(interval, list.expiryStop, list.expiryDone)
+}
+
+// StopExpiry stops the sweeper started by EnableExpiry and waits for it to
+// exit.
+func (list *SkipList[K, V]) StopExpiry() {
+	list.expiryMutex.Lock()
+	defer list.expiryMutex.Unlock()
+
+	list.stopExpiryInternal()
+}
+
+func (list *SkipList[K, V]) stopExpiryInternal() {
+	if list.expiryStop == nil {
+		return
+	}
+
+	close(list.expiryStop)
+	<-list.expiryDone
+	list.expiryStop = nil
+	list.expiryDone = nil
+}
+
+func (list *SkipList[K, V]) sweep(interval time.Duration, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			list.GC()
+		}
+	}
+}
+
+// findLive looks up key under the read lock. An expired node is treated as
+// absent and removed under the write lock. A live node is marked as recently
+// used when touch is set.
+func (list *SkipList[K, V]) findLive(key K, touch bool) *SkipListNode[K, V] {
+	list.mutex.RLock()
+	node := list.findCachedInternal(key)
+	expired := node != nil && list.expired(node)
+	if node != nil && !expired && touch {
+		list.touch(node)
+	}
+	list.mutex.RUnlock()
+
+	if expired {
+		list.removeExpired(key)
+		return nil
+	}
+	return node
+}
+
+// findCachedInternal works like findInternal but first checks the node found
+// by the previous lookup when CacheLastAccess is set. Every path that unlinks
+// a node clears the cache under the write lock, so a cached node is always
+// still in the list.
+func (list *SkipList[K, V]) findCachedInternal(key K) *SkipListNode[K, V] {
+	if !list.cacheLast {
+		return list.findInternal(key, nil)
+	}
+
+	if node := list.lastAccess.Load(); node != nil && node.match(key, list.compare) {
+		return node
+	}
+
+	node := list.findInternal(key, nil)
+	if node != nil {
+		list.lastAccess.Store(node)
+	}
+	return node
+}
+
+func (list *SkipList[K, V]) removeExpired(key K) {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	node := list.findInternal(key, nil)
+	if node != nil && list.expired(node) {
+		list.deleteNode(node)
+	}
+}
+
+func (list *SkipList[K, V]) expired(node *SkipListNode[K, V]) bool {
+	return !node.expireAt.IsZero() && !list.now().Before(node.expireAt)
+}
@@ -0,0 +1,80 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetWithTTL(t *testing.T) {
+	now := time.Unix(0, 0)
+	list, err := NewWithOptions(Options[int, int]{
+		MaxLevel:   5,
+		Comparator: func(a, b int) int { return a - b },
+		Clock:      func() time.Time { return now },
+	})
+	assert.Nil(t, err)
+
+	list.SetWithTTL(1, 1, time.Second)
+	list.Set(2, 2)
+
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, list.Contains(1))
+
+	now = now.Add(500 * time.Millisecond)
+	assert.False(t, list.Contains(1))
+	assert.Equal(t, list.Keys(), []int{2})
+}
+
+func TestStopExpiry(t *testing.T) {
+	list := New[int, int](5)
+
+	list.EnableExpiry(time.Millisecond)
+	first := list.expiryDone
+
+	// restarting replaces the running sweeper
+	list.EnableExpiry(time.Millisecond)
+	select {
+	case <-first:
+	default:
+		t.Fatal("first sweeper still running after restart")
+	}
+
+	done := list.expiryDone
+	list.StopExpiry()
+	select {
+	case <-done:
+	default:
+		t.Fatal("sweeper still running after StopExpiry")
+	}
+	assert.Nil(t, list.expiryDone)
+
+	list.StopExpiry()
+	list.EnableExpiry(0)
+	assert.Nil(t, list.expiryDone)
+}
@@ -0,0 +1,81 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generic
+
+import (
+	"fmt"
+)
+
+// Validate walks every level and returns an error describing the first
+// structural inconsistency it finds: broken back pointers, nodes linked on a
+// level they do not have, keys out of order, or counters that disagree with
+// the nodes.
+func (list *SkipList[K, V]) Validate() error {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	for i := 0; i < list.maxLevel; i++ {
+		distance := 0
+		node := list.head
+		for node != list.tail {
+			next := node.next(i)
+			if next == nil {
+				return fmt.Errorf("skiplist: level %d ends at %v before the tail", i, node.item.key)
+			}
+
+			if next != list.tail && next.nodeLevel() <= i {
+				return fmt.Errorf("skiplist: %v is linked on level %d but has %d levels", next.item.key, i, next.nodeLevel())
+			}
+
+			if next.prevNode[i] != node {
+				return fmt.Errorf("skiplist: %v on level %d does not point back to its predecessor", next.item.key, i)
+			}
+
+			if node != list.head && next != list.tail {
+				result := list.compare(node.item.key, next.item.key)
+				if result > 0 || (result == 0 && !list.multiset) {
+					return fmt.Errorf("skiplist: %v is followed by %v on level %d", node.item.key, next.item.key, i)
+				}
+			}
+
+			distance += node.span[i]
+			node = next
+		}
+
+		if distance != list.length+1 {
+			return fmt.Errorf("skiplist: spans on level %d add up to %d, want %d", i, distance, list.length+1)
+		}
+	}
+
+	size := uint64(0)
+	for node := list.head.nextNode[0]; !node.isEndNode; node = node.nextNode[0] {
+		size += list.entrySize(node.item.key, node.item.value)
+	}
+
+	if size != list.size {
+		return fmt.Errorf("skiplist: size is %d, nodes hold %d", list.size, size)
+	}
+	return nil
+}
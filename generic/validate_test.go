@@ -0,0 +1,87 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generic
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCorrupted(t *testing.T) {
+	testCases := []struct {
+		name    string
+		corrupt func(list *SkipList[string, []byte])
+	}{
+		{
+			name: "order",
+			corrupt: func(list *SkipList[string, []byte]) {
+				list.Front().item.key = "z"
+			},
+		},
+		{
+			name: "back pointer",
+			corrupt: func(list *SkipList[string, []byte]) {
+				list.Back().prevNode[0] = list.head
+			},
+		},
+		{
+			name: "missing level",
+			corrupt: func(list *SkipList[string, []byte]) {
+				list.head.nextNode[list.maxLevel-1] = list.Front()
+			},
+		},
+		{
+			name: "span",
+			corrupt: func(list *SkipList[string, []byte]) {
+				list.head.span[0]++
+			},
+		},
+		{
+			name: "size",
+			corrupt: func(list *SkipList[string, []byte]) {
+				list.size++
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		list, err := NewWithOptions(Options[string, []byte]{
+			MaxLevel:   4,
+			Comparator: strings.Compare,
+			LevelFunc:  func() int { return 1 },
+			KeySize:    func(key string) int { return len(key) },
+		})
+		assert.Nil(t, err)
+		for _, key := range []string{"a", "b", "c", "d"} {
+			list.Set(key, []byte(key))
+		}
+		assert.Nil(t, list.Validate(), testCase.name)
+
+		testCase.corrupt(list)
+		assert.NotNil(t, list.Validate(), testCase.name)
+	}
+}
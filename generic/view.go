@@ -0,0 +1,117 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package generic
+
+// ReadView gives access to the list while WithReadLock holds its read lock.
+// It must not be used after the callback returns.
+type ReadView[K any, V any] struct {
+	list *SkipList[K, V]
+}
+
+// WriteView gives access to the list while WithWriteLock holds its write
+// lock. It must not be used after the callback returns.
+type WriteView[K any, V any] struct {
+	ReadView[K, V]
+}
+
+// WithReadLock runs fn under the read lock so that several reads see the same
+// state. fn must use view instead of the list's own methods, which would
+// deadlock against the held lock.
+func (list *SkipList[K, V]) WithReadLock(fn func(view ReadView[K, V])) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	fn(ReadView[K, V]{list: list})
+}
+
+// WithWriteLock runs fn under the write lock so that a sequence of reads and
+// writes is applied atomically. The same rules as WithReadLock apply.
+func (list *SkipList[K, V]) WithWriteLock(fn func(view WriteView[K, V])) {
+	list.mutex.Lock()
+	defer list.unlock()
+
+	fn(WriteView[K, V]{ReadView[K, V]{list: list}})
+}
+
+func (view ReadView[K, V]) MaxLevel() int {
+	return view.list.maxLevel
+}
+
+func (view ReadView[K, V]) Length() int {
+	return view.list.length
+}
+
+func (view ReadView[K, V]) Size() uint64 {
+	return view.list.size
+}
+
+func (view ReadView[K, V]) Get(key K) *SkipListItem[K, V] {
+	node := view.list.findInternal(key, nil)
+	if node == nil || view.list.expired(node) {
+		return nil
+	}
+	return &node.item
+}
+
+func (view ReadView[K, V]) Contains(key K) bool {
+	return view.Get(key) != nil
+}
+
+func (view ReadView[K, V]) Min() *SkipListItem[K, V] {
+	node := view.list.head.nextNode[0]
+	if node.isEndNode {
+		return nil
+	}
+	return &node.item
+}
+
+func (view ReadView[K, V]) Max() *SkipListItem[K, V] {
+	node := view.list.tail.prevNode[0]
+	if node.isEndNode {
+		return nil
+	}
+	return &node.item
+}
+
+// ForEach works like the list's ForEach within the held lock.
+func (view ReadView[K, V]) ForEach(fn func(key K, value V) bool) {
+	view.list.forEachInternal(fn)
+}
+
+func (view WriteView[K, V]) Set(key K, value V) {
+	view.list.setInternal(key, value)
+}
+
+func (view WriteView[K, V]) Remove(key K) bool {
+	view.list.counters.removes.Add(1)
+
+	node := view.list.findInternal(key, nil)
+	if node == nil {
+		return false
+	}
+
+	view.list.deleteNode(node)
+	return true
+}
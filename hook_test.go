@@ -0,0 +1,109 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnRemove(t *testing.T) {
+	list, clock := newListWithClock(10)
+
+	removed := map[string]int{}
+	list.OnRemove(func(key string, value []byte) {
+		assert.Equal(t, value, []byte("value-"+key))
+		removed[key]++
+		// the lock is released, so calling back into the list must not block
+		list.Length()
+	})
+
+	for i := 0; i < 100; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte("value-"+key))
+	}
+	list.Set("0", []byte("value-0"))
+	assert.Equal(t, len(removed), 0)
+
+	total := 0
+	assert.True(t, list.Remove("10"))
+	assert.False(t, list.Remove("10"))
+	total++
+
+	total += list.DeleteRange("2", "3")
+	total += list.Trim(list.Length() - 5)
+
+	list.PopMin()
+	list.RemoveBack()
+	total += 2
+
+	list.SetWithTTL("expiring", []byte("value-expiring"), time.Second)
+	clock.Advance(2 * time.Second)
+	total += list.GC()
+
+	assert.Equal(t, len(removed), total)
+
+	total += list.Length()
+	list.Clear()
+	assert.Equal(t, len(removed), total)
+
+	for key, count := range removed {
+		assert.Equal(t, count, 1, key)
+	}
+}
+
+func TestOnRemoveEviction(t *testing.T) {
+	list := NewBounded(5, 3)
+
+	var removed []string
+	list.OnRemove(func(key string, value []byte) {
+		removed = append(removed, key)
+	})
+
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		list.Set(key, []byte("value-"+key))
+	}
+	assert.Equal(t, removed, []string{"a", "b"})
+
+	list.SetSizeLimit(10)
+	assert.Equal(t, removed, []string{"a", "b", "c", "d"})
+
+	buffer := &bytes.Buffer{}
+	other := newListWithKeys("x", "y")
+	_, err := other.WriteTo(buffer)
+	assert.Nil(t, err)
+
+	_, err = list.ReadFrom(buffer)
+	assert.Nil(t, err)
+	assert.Equal(t, removed, []string{"a", "b", "c", "d", "e"})
+
+	list.OnRemove(nil)
+	list.Remove("x")
+	assert.Equal(t, len(removed), 5)
+}
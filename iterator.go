@@ -0,0 +1,60 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import "github.com/ISSuh/skiplist/generic"
+
+type Iterator = generic.Iterator[string, []byte]
+
+type ReverseIterator = generic.ReverseIterator[string, []byte]
+
+// ChunkedIterator walks the list in key order while holding the read lock
+// only to load the next chunk of items, as described on
+// generic.ChunkedIterator.
+type ChunkedIterator = generic.ChunkedIterator[string, []byte]
+
+func (list *SkipList) Iterator() *Iterator {
+	return list.core.Iterator()
+}
+
+// SeekGE returns an iterator positioned at the first key greater than or
+// equal to key. It is invalid when every key is smaller.
+func (list *SkipList) SeekGE(key string) *Iterator {
+	return list.core.SeekGE(key)
+}
+
+func (list *SkipList) ReverseIterator() *ReverseIterator {
+	return list.core.ReverseIterator()
+}
+
+// SeekLE returns a reverse iterator positioned at the last key less than or
+// equal to key. It is invalid when every key is larger.
+func (list *SkipList) SeekLE(key string) *ReverseIterator {
+	return list.core.SeekLE(key)
+}
+
+func (list *SkipList) ChunkedIterator(chunkSize int) *ChunkedIterator {
+	return list.core.ChunkedIterator(chunkSize)
+}
@@ -0,0 +1,413 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIteratorEmpty(t *testing.T) {
+	list := New(5)
+
+	it := list.Iterator()
+	assert.False(t, it.Valid())
+	assert.Equal(t, it.Key(), "")
+	assert.Nil(t, it.Value())
+
+	it.Next()
+	assert.False(t, it.Valid())
+
+	it.Seek("a")
+	assert.False(t, it.Valid())
+}
+
+func TestIteratorSingle(t *testing.T) {
+	list := New(5)
+	list.Set("a", []byte("1"))
+
+	it := list.Iterator()
+	if assert.True(t, it.Valid()) {
+		assert.Equal(t, it.Key(), "a")
+		assert.Equal(t, it.Value(), []byte("1"))
+	}
+
+	it.Next()
+	assert.False(t, it.Valid())
+}
+
+func TestIteratorLoop(t *testing.T) {
+	list := New(5)
+
+	var temp []string
+	for i := 0; i < 30; i++ {
+		word := randomString(5)
+		list.Set(word, []byte(word))
+		temp = append(temp, word)
+	}
+
+	sort.Strings(temp)
+
+	var keys []string
+	for it := list.Iterator(); it.Valid(); it.Next() {
+		assert.Equal(t, it.Value(), []byte(it.Key()))
+		keys = append(keys, it.Key())
+	}
+	assert.Equal(t, keys, temp)
+}
+
+func TestIteratorSeek(t *testing.T) {
+	list := New(5)
+
+	for i := 1; i < 10; i += 2 {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	it := list.Iterator()
+
+	it.Seek("3")
+	if assert.True(t, it.Valid()) {
+		assert.Equal(t, it.Key(), "3")
+	}
+
+	it.Seek("4")
+	if assert.True(t, it.Valid()) {
+		assert.Equal(t, it.Key(), "5")
+	}
+
+	it.Seek("0")
+	if assert.True(t, it.Valid()) {
+		assert.Equal(t, it.Key(), "1")
+	}
+
+	it.Seek("91")
+	assert.False(t, it.Valid())
+}
+
+func TestIteratorPrev(t *testing.T) {
+	list := newListWithKeys("a", "b", "c", "d", "e")
+
+	it := list.Iterator()
+	it.Seek("c")
+
+	keys := []string{it.Key()}
+	it.Next()
+	keys = append(keys, it.Key())
+	it.Next()
+	keys = append(keys, it.Key())
+	for i := 0; i < 3; i++ {
+		it.Prev()
+		keys = append(keys, it.Key())
+	}
+	assert.Equal(t, keys, []string{"c", "d", "e", "d", "c", "b"})
+
+	it.Prev()
+	assert.Equal(t, it.Key(), "a")
+	it.Prev()
+	assert.False(t, it.Valid())
+	it.Prev()
+	assert.False(t, it.Valid())
+
+	it.Seek("e")
+	it.Next()
+	assert.False(t, it.Valid())
+	it.Prev()
+	assert.False(t, it.Valid())
+}
+
+func TestIteratorWithClear(t *testing.T) {
+	list := New(10)
+	fill := func() {
+		for i := 0; i < 200; i++ {
+			key := strconv.Itoa(i)
+			list.Set(key, []byte(key))
+		}
+	}
+	fill()
+
+	var wg sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for round := 0; round < 50; round++ {
+				last := ""
+				steps := 0
+				for it := list.Iterator(); it.Valid(); it.Next() {
+					assert.Greater(t, it.Key(), last)
+					assert.Equal(t, string(it.Value()), it.Key())
+					last = it.Key()
+					steps++
+				}
+				assert.LessOrEqual(t, steps, 200)
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for round := 0; round < 50; round++ {
+			list.Clear()
+			fill()
+		}
+	}()
+
+	wg.Wait()
+	assert.Equal(t, list.Length(), 200)
+	checkRanks(t, list)
+}
+
+func TestIteratorValueIsCopy(t *testing.T) {
+	list := New(5)
+	list.Set("a", []byte("value"))
+
+	it := list.Iterator()
+	value := it.Value()
+	value[0] = 'V'
+	assert.Equal(t, it.Value(), []byte("value"))
+	assert.Equal(t, list.Get("a").Value(), []byte("value"))
+
+	reverse := list.ReverseIterator()
+	reverse.Value()[0] = 'V'
+	assert.Equal(t, reverse.Value(), []byte("value"))
+	assert.Equal(t, list.Get("a").Value(), []byte("value"))
+}
+
+func TestIteratorDoesNotSearch(t *testing.T) {
+	compares := 0
+	list := NewWithComparator(5, func(a, b string) int {
+		compares++
+		return strings.Compare(a, b)
+	})
+	for i := 0; i < 100; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	compares = 0
+	count := 0
+	for it := list.Iterator(); it.Valid(); it.Next() {
+		assert.Equal(t, it.Value(), []byte(it.Key()))
+		count++
+	}
+	for it := list.ReverseIterator(); it.Valid(); it.Next() {
+		assert.Equal(t, it.Value(), []byte(it.Key()))
+		count++
+	}
+	assert.Equal(t, count, 200)
+	assert.Equal(t, compares, 0)
+}
+
+func TestReverseIterator(t *testing.T) {
+	list := New(5)
+
+	it := list.ReverseIterator()
+	assert.False(t, it.Valid())
+	it.Next()
+	assert.False(t, it.Valid())
+
+	var temp []string
+	for i := 0; i < 30; i++ {
+		word := randomString(5)
+		list.Set(word, []byte(word))
+		temp = append(temp, word)
+	}
+
+	sort.Sort(sort.Reverse(sort.StringSlice(temp)))
+
+	var keys []string
+	for it := list.ReverseIterator(); it.Valid(); it.Next() {
+		assert.Equal(t, it.Value(), []byte(it.Key()))
+		keys = append(keys, it.Key())
+	}
+	assert.Equal(t, keys, temp)
+}
+
+func TestReverseIteratorSeekForPrev(t *testing.T) {
+	list := New(5)
+	for i := 1; i < 10; i += 2 {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	it := list.ReverseIterator()
+
+	it.SeekForPrev("5")
+	if assert.True(t, it.Valid()) {
+		assert.Equal(t, it.Key(), "5")
+	}
+
+	it.SeekForPrev("4")
+	var keys []string
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, it.Key())
+	}
+	assert.Equal(t, keys, []string{"3", "1"})
+
+	it.SeekForPrev("99")
+	if assert.True(t, it.Valid()) {
+		assert.Equal(t, it.Key(), "9")
+	}
+
+	it.SeekForPrev("0")
+	assert.False(t, it.Valid())
+	assert.Equal(t, it.Key(), "")
+	assert.Nil(t, it.Value())
+}
+
+func TestSeekGEAndSeekLE(t *testing.T) {
+	list := New(5)
+	assert.False(t, list.SeekGE("1").Valid())
+	assert.False(t, list.SeekLE("1").Valid())
+
+	for i := 1; i < 10; i += 2 {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	collect := func(it interface {
+		Valid() bool
+		Next()
+		Key() string
+	}) []string {
+		keys := []string{}
+		for ; it.Valid(); it.Next() {
+			keys = append(keys, it.Key())
+		}
+		return keys
+	}
+
+	assert.Equal(t, collect(list.SeekGE("5")), []string{"5", "7", "9"})
+	assert.Equal(t, collect(list.SeekGE("4")), []string{"5", "7", "9"})
+	assert.Equal(t, collect(list.SeekGE("0")), []string{"1", "3", "5", "7", "9"})
+	assert.Equal(t, collect(list.SeekGE("99")), []string{})
+
+	assert.Equal(t, collect(list.SeekLE("5")), []string{"5", "3", "1"})
+	assert.Equal(t, collect(list.SeekLE("6")), []string{"5", "3", "1"})
+	assert.Equal(t, collect(list.SeekLE("99")), []string{"9", "7", "5", "3", "1"})
+	assert.Equal(t, collect(list.SeekLE("0")), []string{})
+
+	it := list.SeekGE("6")
+	it.Prev()
+	if assert.True(t, it.Valid()) {
+		assert.Equal(t, it.Key(), "5")
+		assert.Equal(t, it.Value(), []byte("5"))
+	}
+}
+
+func TestChunkedIterator(t *testing.T) {
+	list := New(5)
+
+	it := list.ChunkedIterator(3)
+	assert.False(t, it.Valid())
+
+	for i := 0; i < 10; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	for _, chunkSize := range []int{0, 1, 3, 10, 100} {
+		var keys []string
+		for it := list.ChunkedIterator(chunkSize); it.Valid(); it.Next() {
+			assert.Equal(t, it.Value(), []byte(it.Key()))
+			keys = append(keys, it.Key())
+		}
+		assert.Equal(t, keys, list.Keys())
+	}
+}
+
+func TestChunkedIteratorMultiset(t *testing.T) {
+	list, _ := NewWithOptions(Options{MaxLevel: 5, Multiset: true})
+	for i, key := range []string{"a", "a", "a", "b", "c", "c", "c", "c", "c", "d"} {
+		list.Set(key, []byte(key+strconv.Itoa(i)))
+	}
+
+	expected := [][]byte{}
+	for it := list.Iterator(); it.Valid(); it.Next() {
+		expected = append(expected, it.Value())
+	}
+
+	for chunkSize := 1; chunkSize <= 11; chunkSize++ {
+		values := [][]byte{}
+		for it := list.ChunkedIterator(chunkSize); it.Valid() && len(values) <= len(expected); it.Next() {
+			values = append(values, it.Value())
+		}
+		assert.Equal(t, values, expected, "chunk size %d", chunkSize)
+	}
+}
+
+func TestChunkedIteratorWithWriters(t *testing.T) {
+	list := New(10)
+	for i := 0; i < 5000; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	stop := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+
+			key := strconv.Itoa(rand.Intn(10000))
+			if rand.Intn(2) == 0 {
+				list.Set(key, []byte(key))
+			} else {
+				list.Remove(key)
+			}
+		}
+	}()
+
+	for round := 0; round < 5; round++ {
+		prev := ""
+		count := 0
+		for it := list.ChunkedIterator(64); it.Valid(); it.Next() {
+			if count > 0 {
+				assert.Less(t, prev, it.Key())
+			}
+			prev = it.Key()
+			count++
+		}
+		assert.Greater(t, count, 0)
+	}
+
+	close(stop)
+	wg.Wait()
+}
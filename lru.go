@@ -0,0 +1,44 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+// NewBounded creates a list that holds at most maxEntries keys. Inserting past
+// the bound evicts the least recently used key, where Get, Load and Set count
+// as uses.
+func NewBounded(maxLevel int, maxEntries int) *SkipList {
+	list, _ := newList(Options{MaxLevel: maxLevel}, max(maxEntries, 1))
+	return list
+}
+
+// SetSizeLimit bounds the total bytes of keys and values reported by Size.
+// Going over the limit evicts the least recently used key on a bounded list
+// and the smallest key otherwise. A limit of 0 removes the bound.
+func (list *SkipList) SetSizeLimit(maxBytes uint64) {
+	list.core.SetSizeLimit(maxBytes)
+}
+
+func (list *SkipList) SizeLimit() uint64 {
+	return list.core.SizeLimit()
+}
@@ -0,0 +1,182 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBounded(t *testing.T) {
+	list := NewBounded(5, 3)
+
+	list.Set("a", []byte("a"))
+	list.Set("b", []byte("b"))
+	list.Set("c", []byte("c"))
+	assert.Equal(t, list.Keys(), []string{"a", "b", "c"})
+
+	list.Set("d", []byte("d"))
+	assert.Equal(t, list.Keys(), []string{"b", "c", "d"})
+	assert.Equal(t, list.Length(), 3)
+	assert.Equal(t, list.Size(), uint64(6))
+
+	list.Get("b")
+	list.Set("e", []byte("e"))
+	assert.Equal(t, list.Keys(), []string{"b", "d", "e"})
+
+	list.Set("d", []byte("dd"))
+	list.Set("f", []byte("f"))
+	assert.Equal(t, list.Keys(), []string{"d", "e", "f"})
+
+	list.Load("e")
+	list.Contains("d")
+	list.Set("g", []byte("g"))
+	assert.Equal(t, list.Keys(), []string{"e", "f", "g"})
+
+	list.Remove("f")
+	list.Set("h", []byte("h"))
+	list.Set("i", []byte("i"))
+	assert.Equal(t, list.Keys(), []string{"g", "h", "i"})
+	checkRanks(t, list)
+}
+
+func TestNewBoundedFill(t *testing.T) {
+	list := NewBounded(10, 100)
+
+	for i := 0; i < 1000; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	assert.Equal(t, list.Length(), 100)
+	for i := 0; i < 900; i++ {
+		assert.False(t, list.Contains(strconv.Itoa(i)))
+	}
+	for i := 900; i < 1000; i++ {
+		assert.True(t, list.Contains(strconv.Itoa(i)))
+	}
+	checkRanks(t, list)
+
+	var items []SkipListItem
+	for i := 0; i < 300; i++ {
+		key := strconv.Itoa(i)
+		items = append(items, NewItem(key, []byte(key)))
+	}
+	list.SetBatch(items)
+	assert.Equal(t, list.Length(), 100)
+	checkRanks(t, list)
+
+	list.Clear()
+	list.Set("a", []byte("a"))
+	assert.Equal(t, list.Keys(), []string{"a"})
+}
+
+func TestNewBoundedConcurrent(t *testing.T) {
+	list := NewBounded(10, 50)
+
+	wg := &sync.WaitGroup{}
+	wg.Add(8)
+
+	for w := 0; w < 8; w++ {
+		go func(worker int) {
+			for i := 0; i < 1000; i++ {
+				key := strconv.Itoa((i * (worker + 1)) % 200)
+				if i%3 == 0 {
+					list.Set(key, []byte(key))
+				} else {
+					list.Get(key)
+				}
+			}
+			wg.Done()
+		}(w)
+	}
+
+	wg.Wait()
+	assert.LessOrEqual(t, list.Length(), 50)
+	assert.Equal(t, len(list.Keys()), list.Length())
+}
+
+func TestSizeLimit(t *testing.T) {
+	list := New(5)
+	assert.Equal(t, list.SizeLimit(), uint64(0))
+
+	list.SetSizeLimit(20)
+	assert.Equal(t, list.SizeLimit(), uint64(20))
+
+	// every entry takes 1 key byte and 4 value bytes
+	for _, key := range []string{"a", "b", "c", "d"} {
+		list.Set(key, []byte("1234"))
+	}
+	assert.Equal(t, list.Size(), uint64(20))
+	assert.Equal(t, list.Keys(), []string{"a", "b", "c", "d"})
+
+	list.Set("e", []byte("1234"))
+	assert.Equal(t, list.Keys(), []string{"b", "c", "d", "e"})
+	assert.Equal(t, list.Size(), uint64(20))
+
+	list.Set("e", []byte("123456789"))
+	assert.Equal(t, list.Keys(), []string{"c", "d", "e"})
+	assert.Equal(t, list.Size(), uint64(20))
+
+	list.SetBatch([]SkipListItem{
+		NewItem("f", []byte("12")),
+		NewItem("g", []byte("12")),
+		NewItem("h", []byte("12")),
+	})
+	assert.Equal(t, list.Keys(), []string{"e", "f", "g", "h"})
+	assert.Equal(t, list.Size(), uint64(19))
+	checkRanks(t, list)
+
+	list.SetSizeLimit(6)
+	assert.Equal(t, list.Keys(), []string{"g", "h"})
+	assert.Equal(t, list.Size(), uint64(6))
+
+	list.SetSizeLimit(0)
+	list.Set("z", []byte("123456789"))
+	assert.Equal(t, list.Length(), 3)
+	assert.Equal(t, list.Size(), uint64(16))
+}
+
+func TestSizeLimitBounded(t *testing.T) {
+	list := NewBounded(5, 10)
+	list.SetSizeLimit(6)
+
+	list.Set("a", []byte("a"))
+	list.Set("b", []byte("b"))
+	list.Set("c", []byte("c"))
+	list.Get("a")
+
+	list.Set("d", []byte("d"))
+	assert.Equal(t, list.Keys(), []string{"a", "c", "d"})
+	assert.Equal(t, list.Size(), uint64(6))
+
+	list.Set("e", []byte("eee"))
+	assert.Equal(t, list.Keys(), []string{"d", "e"})
+	assert.Equal(t, list.Size(), uint64(6))
+	checkRanks(t, list)
+}
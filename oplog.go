@@ -0,0 +1,50 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import "github.com/ISSuh/skiplist/generic"
+
+type OpKind = generic.OpKind
+
+const (
+	OpSet    = generic.OpSet
+	OpRemove = generic.OpRemove
+)
+
+// Op is one entry of an operation log. Value is ignored for OpRemove.
+type Op = generic.Op[string, []byte]
+
+// Replay builds a list by applying ops in order, which restores the state
+// recorded by an operation log.
+func Replay(maxLevel int, ops []Op) *SkipList {
+	list := New(maxLevel)
+	list.Apply(ops)
+	return list
+}
+
+// Apply applies ops in order under a single write lock.
+func (list *SkipList) Apply(ops []Op) {
+	list.core.Apply(ops)
+}
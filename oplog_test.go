@@ -0,0 +1,73 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplay(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	list := New(10)
+
+	var ops []Op
+	for i := 0; i < 5000; i++ {
+		key := strconv.Itoa(random.Intn(500))
+		if random.Intn(3) == 0 {
+			list.Remove(key)
+			ops = append(ops, Op{Kind: OpRemove, Key: key})
+			continue
+		}
+
+		value := []byte(strconv.Itoa(i))
+		list.Set(key, value)
+		ops = append(ops, Op{Kind: OpSet, Key: key, Value: value})
+	}
+
+	replayed := Replay(10, ops)
+	assert.True(t, replayed.Equal(list))
+	assert.Equal(t, replayed.Length(), list.Length())
+	assert.Equal(t, replayed.Size(), list.Size())
+	checkRanks(t, replayed)
+
+	assert.True(t, Replay(5, nil).IsEmpty())
+}
+
+func TestApply(t *testing.T) {
+	list := newListWithKeys("a", "b")
+	list.Apply([]Op{
+		{Kind: OpRemove, Key: "a"},
+		{Kind: OpSet, Key: "c", Value: []byte("c")},
+		{Kind: OpRemove, Key: "missing"},
+		{Kind: OpSet, Key: "b", Value: []byte("bb")},
+	})
+
+	assert.Equal(t, list.Keys(), []string{"b", "c"})
+	assert.Equal(t, list.Get("b").Value(), []byte("bb"))
+}
@@ -0,0 +1,122 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/ISSuh/skiplist/generic"
+)
+
+var ErrInvalidProbability = generic.ErrInvalidProbability
+
+type Options struct {
+	// MaxLevel is the maximum height of a node. Values below 1 are raised to 1.
+	MaxLevel int
+
+	// Probability is the chance a node is promoted to the next level.
+	// Zero selects the default of 0.5.
+	Probability float64
+
+	// Comparator orders the keys. Nil selects lexicographic order.
+	Comparator func(a, b string) int
+
+	// Source seeds the level generator. Nil selects a time based seed.
+	Source rand.Source
+
+	// Growable raises MaxLevel by one each time the length exceeds
+	// 2^MaxLevel, so the list keeps logarithmic lookups as it grows.
+	Growable bool
+
+	// Multiset makes Set always insert, so a key may be stored several times.
+	// Lookups by key find the oldest entry; use EqualRange to get them all.
+	Multiset bool
+
+	// ShareValues stores the slices passed to Set as they are instead of
+	// copying them. It saves an allocation per write, but the caller must
+	// not modify a slice after handing it to the list. Values are copied by
+	// default.
+	ShareValues bool
+
+	// LevelFunc picks the height of each new node in place of the random
+	// generator, which makes the shape of the list reproducible. Results
+	// outside [1, MaxLevel] are clamped into that range.
+	LevelFunc func() int
+
+	// CacheLastAccess remembers the node found by the last lookup so that
+	// repeated reads of a hot key skip the search.
+	CacheLastAccess bool
+
+	// PoolNodes reuses the nodes of removed entries for later inserts, which
+	// cuts allocations under insert and delete churn. Items, nodes and
+	// iterators obtained from the list must then not be used once their entry
+	// has been removed, because the node may already hold another entry.
+	PoolNodes bool
+
+	// Clock tells the time for TTLs. Nil selects time.Now.
+	Clock func() time.Time
+}
+
+func NewWithOptions(options Options) (*SkipList, error) {
+	return newList(options, 0)
+}
+
+// newList builds the generic list behind a SkipList, measuring entries in
+// bytes. maxEntries bounds it like NewBounded when positive.
+func newList(options Options, maxEntries int) (*SkipList, error) {
+	compare := options.Comparator
+	if compare == nil {
+		compare = strings.Compare
+	}
+
+	store := copyBytes
+	if options.ShareValues {
+		store = shareBytes
+	}
+
+	core, err := generic.NewWithOptions(generic.Options[string, []byte]{
+		MaxLevel:        options.MaxLevel,
+		Probability:     options.Probability,
+		Comparator:      compare,
+		Source:          options.Source,
+		Growable:        options.Growable,
+		Multiset:        options.Multiset,
+		LevelFunc:       options.LevelFunc,
+		CacheLastAccess: options.CacheLastAccess,
+		PoolNodes:       options.PoolNodes,
+		MaxEntries:      maxEntries,
+		Clock:           options.Clock,
+		KeySize:         func(key string) int { return len(key) },
+		ValueSize:       func(value []byte) int { return len(value) },
+		CopyValue:       copyBytes,
+		StoreValue:      store,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &SkipList{core: core, shared: options.ShareValues}, nil
+}
@@ -0,0 +1,345 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func averageLevel(list *SkipList) float64 {
+	levels := nodeLevels(list)
+
+	sum := 0
+	for _, level := range levels {
+		sum += level
+	}
+	return float64(sum) / float64(len(levels))
+}
+
+func TestNewWithOptions(t *testing.T) {
+	list, err := NewWithOptions(Options{MaxLevel: 5})
+	assert.Nil(t, err)
+	if assert.NotNil(t, list) {
+		assert.Equal(t, list.MaxLevel(), 5)
+
+		list.Set("b", []byte("b"))
+		list.Set("a", []byte("a"))
+		assert.Equal(t, list.Keys(), []string{"a", "b"})
+	}
+
+	list, err = NewWithOptions(Options{MaxLevel: 5, Comparator: numericCompare})
+	assert.Nil(t, err)
+	list.Set("10", []byte("10"))
+	list.Set("9", []byte("9"))
+	assert.Equal(t, list.Keys(), []string{"9", "10"})
+}
+
+func TestInvalidProbability(t *testing.T) {
+	for _, probability := range []float64{-0.5, 1, 1.5, math.NaN(), math.Inf(1)} {
+		list, err := NewWithOptions(Options{MaxLevel: 5, Probability: probability})
+		assert.Equal(t, err, ErrInvalidProbability)
+		assert.Nil(t, list)
+	}
+}
+
+func TestProbability(t *testing.T) {
+	half, err := NewWithOptions(Options{MaxLevel: 16, Probability: 0.5, Source: rand.NewSource(42)})
+	assert.Nil(t, err)
+
+	quarter, err := NewWithOptions(Options{MaxLevel: 16, Probability: 0.25, Source: rand.NewSource(42)})
+	assert.Nil(t, err)
+
+	for i := 0; i < 10000; i++ {
+		key := strconv.Itoa(i)
+		half.Set(key, []byte(key))
+		quarter.Set(key, []byte(key))
+	}
+
+	assert.InDelta(t, averageLevel(half), 2.0, 0.1)
+	assert.InDelta(t, averageLevel(quarter), 4.0/3.0, 0.1)
+	assert.Greater(t, averageLevel(half), averageLevel(quarter))
+}
+
+func TestGrowable(t *testing.T) {
+	list, err := NewWithOptions(Options{MaxLevel: 4, Growable: true, Source: rand.NewSource(42)})
+	assert.Nil(t, err)
+
+	fixed := New(4)
+
+	for i := 0; i < 100000; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+		if i < 100 {
+			fixed.Set(key, []byte(key))
+		}
+	}
+
+	assert.Equal(t, list.MaxLevel(), 17)
+	assert.Equal(t, fixed.MaxLevel(), 4)
+	assert.Equal(t, list.Length(), 100000)
+	assert.Greater(t, len(list.LevelHistogram()), 4)
+
+	for i := 0; i < 100000; i += 997 {
+		key := strconv.Itoa(i)
+		assert.Equal(t, list.Get(key).Value(), []byte(key))
+
+		rank, found := list.Rank(key)
+		if assert.True(t, found) {
+			assert.Equal(t, list.Select(rank).Key(), key)
+		}
+	}
+
+	for i := 0; i < 100000; i += 2 {
+		list.Remove(strconv.Itoa(i))
+	}
+	assert.Equal(t, list.Length(), 50000)
+	assert.Equal(t, list.Get("1").Value(), []byte("1"))
+	assert.Equal(t, list.Get("2"), (*SkipListItem)(nil))
+}
+
+func TestGrowableBatch(t *testing.T) {
+	list, err := NewWithOptions(Options{MaxLevel: 2, Growable: true})
+	assert.Nil(t, err)
+
+	var items []SkipListItem
+	for i := 0; i < 1000; i++ {
+		key := strconv.Itoa(i)
+		items = append(items, NewItem(key, []byte(key)))
+	}
+
+	list.SetBatch(items)
+	assert.Equal(t, list.MaxLevel(), 10)
+	assert.Equal(t, list.Length(), 1000)
+	checkRanks(t, list)
+}
+
+func nodeValues(nodes []*SkipListNode) []string {
+	values := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		values = append(values, string(node.Value()))
+	}
+	return values
+}
+
+func TestMultiset(t *testing.T) {
+	list, err := NewWithOptions(Options{MaxLevel: 5, Multiset: true})
+	assert.Nil(t, err)
+
+	assert.Nil(t, list.EqualRange("a"))
+
+	list.Set("b", []byte("b1"))
+	list.Set("a", []byte("a1"))
+	list.Set("b", []byte("b2"))
+	list.Set("c", []byte("c1"))
+	list.Set("b", []byte("b3"))
+	list.SetBatch([]SkipListItem{NewItem("b", []byte("b4")), NewItem("a", []byte("a2"))})
+
+	assert.Equal(t, list.Length(), 7)
+	assert.Equal(t, list.Keys(), []string{"a", "a", "b", "b", "b", "b", "c"})
+	assert.Equal(t, nodeValues(list.EqualRange("b")), []string{"b1", "b2", "b3", "b4"})
+	assert.Equal(t, nodeValues(list.EqualRange("a")), []string{"a1", "a2"})
+	assert.Nil(t, list.EqualRange("d"))
+
+	assert.Equal(t, list.Get("b").Value(), []byte("b1"))
+	assert.True(t, list.Remove("b"))
+	assert.Equal(t, nodeValues(list.EqualRange("b")), []string{"b2", "b3", "b4"})
+
+	for i, key := range list.Keys() {
+		assert.Equal(t, list.Select(i).Key(), key)
+	}
+	rank, _ := list.Rank("b")
+	assert.Equal(t, rank, 2)
+}
+
+func TestEqualRangeUnique(t *testing.T) {
+	list := New(5)
+	list.Set("a", []byte("1"))
+	list.Set("a", []byte("2"))
+
+	assert.Equal(t, nodeValues(list.EqualRange("a")), []string{"2"})
+}
+
+func TestShareValues(t *testing.T) {
+	copied, err := NewWithOptions(Options{MaxLevel: 5})
+	assert.Nil(t, err)
+
+	shared, err := NewWithOptions(Options{MaxLevel: 5, ShareValues: true})
+	assert.Nil(t, err)
+
+	buffer := []byte("value")
+	copied.Set("k", buffer)
+	shared.Set("k", buffer)
+	buffer[0] = 'X'
+
+	assert.Equal(t, copied.Get("k").Value(), []byte("value"))
+	assert.Equal(t, shared.Get("k").Value(), []byte("Xalue"))
+
+	buffer = []byte("other")
+	copied.Set("k", buffer)
+	shared.Set("k", buffer)
+	buffer[0] = 'X'
+
+	assert.Equal(t, copied.Get("k").Value(), []byte("other"))
+	assert.Equal(t, shared.Get("k").Value(), []byte("Xther"))
+
+	shared.Set("nil", nil)
+	assert.NotNil(t, shared.Get("nil").Value())
+
+	clone := shared.Clone()
+	buffer[0] = 'Y'
+	assert.Equal(t, clone.Get("k").Value(), []byte("Xther"))
+	assert.Equal(t, shared.Get("k").Value(), []byte("Yther"))
+}
+
+func TestLevelFunc(t *testing.T) {
+	levels := []int{1, 3, 2, 0, 9}
+	next := 0
+	list, err := NewWithOptions(Options{
+		MaxLevel: 4,
+		LevelFunc: func() int {
+			level := levels[next%len(levels)]
+			next++
+			return level
+		},
+	})
+	assert.Nil(t, err)
+
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		list.Set(key, []byte(key))
+	}
+
+	assert.Equal(t, nodeLevels(list), []int{1, 3, 2, 1, 4})
+	assert.Equal(t, list.Front().Key(), "a")
+	assert.Equal(t, list.Back().Key(), "e")
+	assert.Equal(t, list.Get("c").Value(), []byte("c"))
+	assert.Equal(t, list.Get("e").Value(), []byte("e"))
+	assert.Nil(t, list.Get("f"))
+	checkRanks(t, list)
+
+	clone := list.Clone()
+	clone.Set("f", []byte("f"))
+	assert.Equal(t, nodeLevels(clone), []int{1, 3, 2, 1, 4, 1})
+}
+
+func TestCacheLastAccess(t *testing.T) {
+	list, err := NewWithOptions(Options{MaxLevel: 10, CacheLastAccess: true})
+	assert.Nil(t, err)
+
+	for i := 0; i < 100; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	assert.Equal(t, list.Get("42").Value(), []byte("42"))
+	assert.Equal(t, list.Get("42").Value(), []byte("42"))
+	assert.Equal(t, list.Get("43").Value(), []byte("43"))
+	assert.Nil(t, list.Get("4a"))
+
+	list.Set("43", []byte("changed"))
+	assert.Equal(t, list.Get("43").Value(), []byte("changed"))
+
+	list.Remove("43")
+	assert.Nil(t, list.Get("43"))
+	assert.False(t, list.Contains("43"))
+
+	list.Set("43", []byte("again"))
+	assert.Equal(t, list.Get("43").Value(), []byte("again"))
+
+	list.Get("50")
+	list.DeleteRange("5", "6")
+	assert.Nil(t, list.Get("50"))
+
+	list.Get("60")
+	list.PopMax()
+	list.Trim(10)
+	assert.Nil(t, list.Get("60"))
+
+	list.Get("1")
+	list.Clear()
+	assert.Nil(t, list.Get("1"))
+	assert.True(t, list.IsEmpty())
+}
+
+func TestCacheLastAccessConcurrent(t *testing.T) {
+	list, err := NewWithOptions(Options{MaxLevel: 10, CacheLastAccess: true})
+	assert.Nil(t, err)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := strconv.Itoa(i % 10)
+				switch {
+				case w == 0 && i%3 == 0:
+					list.Remove(key)
+				case w == 1:
+					list.Set(key, []byte(key))
+				default:
+					if value, ok := list.Load(key); ok {
+						assert.Equal(t, value, []byte(key))
+					}
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	assert.Nil(t, list.Validate())
+	for _, key := range list.Keys() {
+		assert.True(t, list.Contains(key))
+	}
+}
+
+func BenchmarkGetHotKey(b *testing.B) {
+	b.ReportAllocs()
+	list := New(15)
+	benchmarkGetHotKey(b, list)
+}
+
+func BenchmarkGetHotKeyCached(b *testing.B) {
+	b.ReportAllocs()
+	list, _ := NewWithOptions(Options{MaxLevel: 15, CacheLastAccess: true})
+	benchmarkGetHotKey(b, list)
+}
+
+func benchmarkGetHotKey(b *testing.B, list *SkipList) {
+	for i := 0; i < 100000; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		list.Get("54321")
+	}
+}
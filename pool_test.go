@@ -0,0 +1,147 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPooledList(t testing.TB) *SkipList {
+	list, err := NewWithOptions(Options{MaxLevel: 10, PoolNodes: true})
+	assert.Nil(t, err)
+	return list
+}
+
+func TestPoolNodesChurn(t *testing.T) {
+	list := newPooledList(t)
+	expected := map[string]string{}
+
+	for i := 0; i < 5000; i++ {
+		key := strconv.Itoa(i * 7 % 300)
+		if i%3 == 0 {
+			assert.Equal(t, list.Remove(key), expected[key] != "")
+			delete(expected, key)
+			continue
+		}
+
+		value := strconv.Itoa(i)
+		list.Set(key, []byte(value))
+		expected[key] = value
+	}
+
+	checkRanks(t, list)
+	assert.Equal(t, list.Length(), len(expected))
+	for key, value := range expected {
+		got, ok := list.Load(key)
+		assert.True(t, ok)
+		assert.Equal(t, string(got), value)
+	}
+}
+
+func TestPoolNodesPopAndGC(t *testing.T) {
+	now := time.Now()
+	list, err := NewWithOptions(Options{MaxLevel: 10, PoolNodes: true, Clock: func() time.Time { return now }})
+	assert.Nil(t, err)
+	for i := 0; i < 10; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	item, ok := list.PopMin()
+	assert.True(t, ok)
+	list.Set("x", []byte("x"))
+	assert.Equal(t, item.Key(), "0")
+	assert.Equal(t, item.Value(), []byte("0"))
+
+	for i := 1; i < 10; i += 2 {
+		list.SetWithTTL(strconv.Itoa(i), []byte("ttl"), time.Millisecond)
+	}
+	now = now.Add(time.Second)
+	assert.Equal(t, list.GC(), 5)
+	assert.Equal(t, list.Keys(), []string{"2", "4", "6", "8", "x"})
+	checkRanks(t, list)
+}
+
+func TestPoolNodesEvictedOnInsert(t *testing.T) {
+	list := newPooledList(t)
+	list.SetSizeLimit(4)
+	list.Set("b", []byte("bb"))
+
+	// the new entry is the front of the list, so its own insert evicts it
+	value, loaded := list.GetOrSet("a", []byte("aaaa"))
+	assert.False(t, loaded)
+	assert.Equal(t, value, []byte("aaaa"))
+	assert.Equal(t, list.Keys(), []string{"b"})
+
+	list.Set("c", []byte("c"))
+	assert.Equal(t, list.Keys(), []string{"c"})
+	assert.Equal(t, list.Get("c").Value(), []byte("c"))
+	checkRanks(t, list)
+}
+
+func TestPoolNodesWithRemoveHook(t *testing.T) {
+	list := newPooledList(t)
+	var removed []string
+	list.OnRemove(func(key string, value []byte) {
+		removed = append(removed, key)
+	})
+
+	list.Set("a", []byte("1"))
+	list.Set("b", []byte("2"))
+	list.Remove("a")
+	list.Set("c", []byte("3"))
+
+	assert.Equal(t, removed, []string{"a"})
+	assert.Equal(t, list.Keys(), []string{"b", "c"})
+}
+
+func BenchmarkChurn(b *testing.B) {
+	b.ReportAllocs()
+	benchmarkChurn(b, New(15))
+}
+
+func BenchmarkChurnPooled(b *testing.B) {
+	b.ReportAllocs()
+	benchmarkChurn(b, newPooledList(b))
+}
+
+func benchmarkChurn(b *testing.B, list *SkipList) {
+	keys := make([]string, 1024)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+		list.Set(keys[i], nil)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		key := keys[i%len(keys)]
+		list.Remove(key)
+		list.Set(key, nil)
+	}
+}
@@ -0,0 +1,43 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+// Intersection returns a new list with the keys present in both lists. The
+// values and settings come from the receiver.
+func (list *SkipList) Intersection(other *SkipList) *SkipList {
+	return list.wrap(list.core.Intersection(other.core))
+}
+
+// Union returns a new list with the keys present in either list. When both
+// hold a key the receiver's value wins. Settings come from the receiver.
+func (list *SkipList) Union(other *SkipList) *SkipList {
+	return list.wrap(list.core.Union(other.core))
+}
+
+// Difference returns a new list with the keys of the receiver that are absent
+// from other. Settings come from the receiver.
+func (list *SkipList) Difference(other *SkipList) *SkipList {
+	return list.wrap(list.core.Difference(other.core))
+}
@@ -0,0 +1,101 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newListWithValues(value string, keys ...string) *SkipList {
+	list := New(5)
+	for _, key := range keys {
+		list.Set(key, []byte(value))
+	}
+	return list
+}
+
+func TestIntersection(t *testing.T) {
+	list := newListWithValues("left", "a", "b", "c", "d", "f")
+	other := newListWithValues("right", "b", "d", "e", "f", "g")
+
+	result := list.Intersection(other)
+	assert.Equal(t, result.Keys(), []string{"b", "d", "f"})
+	assert.Equal(t, result.Values(), [][]byte{[]byte("left"), []byte("left"), []byte("left")})
+	checkRanks(t, result)
+
+	assert.Equal(t, other.Intersection(list).Values()[0], []byte("right"))
+	assert.Equal(t, list.Intersection(newListWithValues("x", "x", "y")).Length(), 0)
+	assert.Equal(t, list.Intersection(New(5)).Length(), 0)
+	assert.True(t, list.Intersection(list).Equal(list))
+
+	result.Set("b", []byte("changed"))
+	assert.Equal(t, list.Get("b").Value(), []byte("left"))
+	assert.Equal(t, list.Length(), 5)
+	assert.Equal(t, other.Length(), 5)
+}
+
+func TestUnion(t *testing.T) {
+	list := newListWithValues("left", "a", "c", "e")
+	other := newListWithValues("right", "b", "c", "d", "f")
+
+	result := list.Union(other)
+	assert.Equal(t, result.Keys(), []string{"a", "b", "c", "d", "e", "f"})
+	assert.Equal(t, result.Get("c").Value(), []byte("left"))
+	assert.Equal(t, result.Get("b").Value(), []byte("right"))
+	assert.Equal(t, result.Get("f").Value(), []byte("right"))
+	assert.Equal(t, result.Size(), uint64(6+3*4+3*5))
+	checkRanks(t, result)
+
+	disjoint := list.Union(newListWithValues("right", "x", "y"))
+	assert.Equal(t, disjoint.Keys(), []string{"a", "c", "e", "x", "y"})
+
+	assert.True(t, list.Union(New(5)).Equal(list))
+	assert.True(t, New(5).Union(list).Equal(list))
+	assert.True(t, list.Union(list).Equal(list))
+	assert.Equal(t, list.Length(), 3)
+	assert.Equal(t, other.Length(), 4)
+}
+
+func TestDifference(t *testing.T) {
+	list := newListWithValues("left", "a", "b", "c", "d")
+
+	assert.Equal(t, list.Difference(list).Length(), 0)
+	assert.Equal(t, list.Difference(newListWithValues("right", "d", "c", "b", "a", "z")).Length(), 0)
+
+	disjoint := list.Difference(newListWithValues("right", "x", "y"))
+	assert.True(t, disjoint.Equal(list))
+	assert.True(t, list.Difference(New(5)).Equal(list))
+
+	result := list.Difference(newListWithValues("right", "0", "b", "bb", "d"))
+	assert.Equal(t, result.Keys(), []string{"a", "c"})
+	assert.Equal(t, result.Values(), [][]byte{[]byte("left"), []byte("left")})
+	assert.Equal(t, result.Size(), uint64(10))
+	checkRanks(t, result)
+
+	assert.Equal(t, New(5).Difference(list).Length(), 0)
+	assert.Equal(t, list.Length(), 4)
+}
@@ -0,0 +1,78 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"hash/fnv"
+)
+
+// ShardedSkipList spreads keys over independent lists by hash so writers to
+// different shards do not contend on the same lock. Keys are only ordered
+// within a shard; there is no ordered iteration across the whole set.
+type ShardedSkipList struct {
+	shards []*SkipList
+}
+
+func NewSharded(shards int, maxLevel int) *ShardedSkipList {
+	shards = max(shards, 1)
+	sharded := &ShardedSkipList{
+		shards: make([]*SkipList, shards),
+	}
+
+	for i := range sharded.shards {
+		sharded.shards[i] = New(maxLevel)
+	}
+	return sharded
+}
+
+func (sharded *ShardedSkipList) Shards() int {
+	return len(sharded.shards)
+}
+
+func (sharded *ShardedSkipList) Length() int {
+	length := 0
+	for _, shard := range sharded.shards {
+		length += shard.Length()
+	}
+	return length
+}
+
+func (sharded *ShardedSkipList) Set(key string, value []byte) {
+	sharded.shard(key).Set(key, value)
+}
+
+func (sharded *ShardedSkipList) Get(key string) *SkipListItem {
+	return sharded.shard(key).Get(key)
+}
+
+func (sharded *ShardedSkipList) Remove(key string) bool {
+	return sharded.shard(key).Remove(key)
+}
+
+func (sharded *ShardedSkipList) shard(key string) *SkipList {
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return sharded.shards[hash.Sum32()%uint32(len(sharded.shards))]
+}
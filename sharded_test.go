@@ -0,0 +1,97 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSharded(t *testing.T) {
+	sharded := NewSharded(16, 10)
+	assert.Equal(t, sharded.Shards(), 16)
+	assert.Equal(t, sharded.Length(), 0)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				key := strconv.Itoa(w*500 + i)
+				sharded.Set(key, []byte(key))
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	assert.Equal(t, sharded.Length(), 4000)
+
+	used := 0
+	for _, shard := range sharded.shards {
+		if shard.Length() > 0 {
+			used++
+		}
+	}
+	assert.Equal(t, used, 16)
+
+	item := sharded.Get("1234")
+	assert.NotNil(t, item)
+	assert.Equal(t, item.Value(), []byte("1234"))
+	assert.Nil(t, sharded.Get("unknown"))
+
+	assert.True(t, sharded.Remove("1234"))
+	assert.False(t, sharded.Remove("1234"))
+	assert.Nil(t, sharded.Get("1234"))
+	assert.Equal(t, sharded.Length(), 3999)
+
+	assert.Equal(t, NewSharded(0, 10).Shards(), 1)
+}
+
+func BenchmarkSetParallelSingle(b *testing.B) {
+	list := New(15)
+	benchmarkSetParallel(b, list.Set)
+}
+
+func BenchmarkSetParallelSharded16(b *testing.B) {
+	sharded := NewSharded(16, 15)
+	benchmarkSetParallel(b, sharded.Set)
+}
+
+func benchmarkSetParallel(b *testing.B, set func(key string, value []byte)) {
+	b.ReportAllocs()
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := strconv.Itoa(i % 100000)
+			set(key, []byte(key))
+			i++
+		}
+	})
+}
@@ -27,255 +27,491 @@ SOFTWARE.
 
 // Example
 
-// list := skipList.New(5)
-// list.Set("key", "value")
+// list := skiplist.New(5)
+// list.Set("key", []byte("value"))
 
 // item := list.Get("key")
-// fmt.Printf("key : %s / value : %s", item.key, item.value)
+// fmt.Printf("key : %s / value : %s", item.Key(), item.Value())
+// fmt.Println(list)
 
 package skiplist
 
 import (
+	"bytes"
+	"context"
 	"math/rand"
-	"sync"
+	"slices"
+	"strings"
 	"time"
+
+	"github.com/ISSuh/skiplist/generic"
+)
+
+const (
+	minLevel       = 1
+	defaultPromote = 1 << 30
 )
 
-type SkipListItem struct {
-	key   string
-	value []byte
+var ErrIndexOutOfRange = generic.ErrIndexOutOfRange
+
+var ErrOutOfOrder = generic.ErrOutOfOrder
+
+var ErrLevelOutOfRange = generic.ErrLevelOutOfRange
+
+type SkipListItem = generic.SkipListItem[string, []byte]
+
+func NewItem(key string, value []byte) SkipListItem {
+	return generic.NewItem(key, value)
 }
 
-func (item *SkipListItem) Key() string {
-	return item.key
+type SkipListNode = generic.SkipListNode[string, []byte]
+
+// SkipList is built on generic.SkipList[string, []byte]. It measures entries
+// in bytes and copies values on the way in and out, and adds the operations
+// that only make sense for string keys and byte values.
+type SkipList struct {
+	core   *generic.SkipList[string, []byte]
+	shared bool
 }
 
-func (item *SkipListItem) Value() []byte {
-	return item.value
+func New(maxLevel int) *SkipList {
+	return NewWithComparator(maxLevel, strings.Compare)
 }
 
-type SkipListNode struct {
-	levels    int
-	prevNode  []*SkipListNode
-	nextNode  []*SkipListNode
-	item      SkipListItem
-	isEndNode bool
+func NewWithComparator(maxLevel int, compare func(a, b string) int) *SkipList {
+	list, _ := newList(Options{MaxLevel: maxLevel, Comparator: compare}, 0)
+	return list
 }
 
-func (node *SkipListNode) Next() *SkipListNode {
-	if node.nextNode[0] != nil && node.nextNode[0].isEndNode {
-		return nil
-	}
-	return node.nextNode[0]
+func NewWithRand(maxLevel int, src rand.Source) *SkipList {
+	list, _ := newList(Options{MaxLevel: maxLevel, Source: src}, 0)
+	return list
 }
 
-func (node *SkipListNode) Prev() *SkipListNode {
-	if node.prevNode[0] != nil && node.prevNode[0].isEndNode {
-		return nil
-	}
-	return node.prevNode[0]
+// wrap returns a list around core with the same value sharing as this one.
+func (list *SkipList) wrap(core *generic.SkipList[string, []byte]) *SkipList {
+	return &SkipList{core: core, shared: list.shared}
 }
 
-func (node *SkipListNode) Key() string {
-	return node.item.key
+func (list *SkipList) MaxLevel() int {
+	return list.core.MaxLevel()
 }
 
-func (node *SkipListNode) Value() []byte {
-	return node.item.value
+func (list *SkipList) Length() int {
+	return list.core.Length()
 }
 
-func (node *SkipListNode) next(targetLevel int) *SkipListNode {
-	if node.levels < targetLevel {
-		return nil
-	}
-	return node.nextNode[targetLevel]
+func (list *SkipList) Size() uint64 {
+	return list.core.Size()
 }
 
-func (node *SkipListNode) match(key string) bool {
-	return key == node.item.key
+// MaxKeyLen returns the length of the longest key stored since the list was
+// created or last cleared. Removing keys does not lower it; Compact
+// recomputes it from the remaining entries.
+func (list *SkipList) MaxKeyLen() int {
+	return list.core.MaxKeyLen()
 }
 
-func (node *SkipListNode) nodeLevel() int {
-	return node.levels
+// MaxValueLen returns the length of the longest value stored, with the same
+// rules as MaxKeyLen.
+func (list *SkipList) MaxValueLen() int {
+	return list.core.MaxValueLen()
 }
 
-func (node *SkipListNode) appendOnLevel(newNode *SkipListNode, targetLevel int) {
-	if node.nextNode[targetLevel] != nil {
-		node.nextNode[targetLevel].prevNode[targetLevel] = newNode
-	}
+// ApproxMemoryBytes estimates the memory held by the list, including the
+// per-node struct and pointer slices that Size leaves out.
+func (list *SkipList) ApproxMemoryBytes() uint64 {
+	return list.core.ApproxMemoryBytes()
+}
 
-	newNode.prevNode[targetLevel] = node
-	newNode.nextNode[targetLevel] = node.nextNode[targetLevel]
+func (list *SkipList) LevelHistogram() []int {
+	return list.core.LevelHistogram()
+}
 
-	node.nextNode[targetLevel] = newNode
+func (list *SkipList) String() string {
+	return list.core.String()
 }
 
-func (node *SkipListNode) removeOnLevel(targetLevel int) {
-	if node.nextNode[targetLevel] != nil {
-		node.nextNode[targetLevel].prevNode[targetLevel] = node.prevNode[targetLevel]
-	}
+func (list *SkipList) IsEmpty() bool {
+	return list.core.IsEmpty()
+}
 
-	if node.prevNode[targetLevel] != nil {
-		node.prevNode[targetLevel].nextNode[targetLevel] = node.nextNode[targetLevel]
-	}
+func (list *SkipList) Front() *SkipListNode {
+	return list.core.Front()
 }
 
-type SkipList struct {
-	maxLevel int
-	length   int
-	size     uint64
-	head     *SkipListNode
-	tail     *SkipListNode
-	rand     *rand.Rand
-	mutex    sync.RWMutex
-	history  []*SkipListNode
+func (list *SkipList) Back() *SkipListNode {
+	return list.core.Back()
 }
 
-func New(maxLevel int) *SkipList {
-	headNode := &SkipListNode{
-		levels:    maxLevel,
-		prevNode:  make([]*SkipListNode, maxLevel),
-		nextNode:  make([]*SkipListNode, maxLevel),
-		item:      SkipListItem{},
-		isEndNode: true,
-	}
+func (list *SkipList) Min() *SkipListItem {
+	return list.core.Min()
+}
 
-	tailNode := &SkipListNode{
-		levels:    maxLevel,
-		prevNode:  make([]*SkipListNode, maxLevel),
-		nextNode:  make([]*SkipListNode, maxLevel),
-		item:      SkipListItem{},
-		isEndNode: true,
-	}
+func (list *SkipList) Max() *SkipListItem {
+	return list.core.Max()
+}
 
-	list := SkipList{
-		maxLevel: maxLevel,
-		length:   0,
-		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
-		head:     headNode,
-		tail:     tailNode,
-		history:  make([]*SkipListNode, maxLevel),
-	}
+// First returns copies of up to n items with the smallest keys, in ascending order.
+func (list *SkipList) First(n int) []*SkipListItem {
+	return list.core.First(n)
+}
 
-	for i := 0; i < maxLevel; i++ {
-		list.head.appendOnLevel(list.tail, i)
+// Last returns copies of up to n items with the largest keys, in ascending order.
+func (list *SkipList) Last(n int) []*SkipListItem {
+	return list.core.Last(n)
+}
+
+func (list *SkipList) Keys() []string {
+	return list.core.Keys()
+}
+
+// KeysPage returns up to limit keys strictly greater than after, in order.
+// An empty after starts from the first key, so the last key of a page can be
+// passed back in to fetch the next one.
+func (list *SkipList) KeysPage(after string, limit int) []string {
+	if after == "" {
+		return list.core.KeysPage(nil, limit)
 	}
+	return list.core.KeysPage(&after, limit)
+}
 
-	return &list
+func (list *SkipList) Values() [][]byte {
+	return list.core.Values()
 }
 
-func (list *SkipList) MaxLevel() int {
-	return list.maxLevel
+// ToMap returns the entries as a map with copies of the values.
+func (list *SkipList) ToMap() map[string][]byte {
+	entries := make(map[string][]byte)
+	list.core.ForEach(func(key string, value []byte) bool {
+		if _, exist := entries[key]; !exist {
+			entries[key] = value
+		}
+		return true
+	})
+	return entries
 }
 
-func (list *SkipList) Length() int {
-	return list.length
+// Set stores a copy of value under key, or value itself when the list was
+// created with ShareValues. A nil value is stored as an empty, non-nil slice.
+func (list *SkipList) Set(key string, value []byte) {
+	list.core.Set(key, value)
 }
 
-func (list *SkipList) Size() uint64 {
-	return list.size
+// SetWithLevel works like Set but gives a newly inserted node exactly level
+// levels instead of a random height. An existing node of another height is
+// relinked at level. It returns ErrLevelOutOfRange unless level is between 1
+// and MaxLevel.
+func (list *SkipList) SetWithLevel(key string, value []byte, level int) error {
+	return list.core.SetWithLevel(key, value, level)
 }
 
-func (list *SkipList) Front() *SkipListNode {
-	return list.head.nextNode[0]
+// TrySet works like Set but gives up and returns false if the write lock
+// cannot be acquired within timeout.
+func (list *SkipList) TrySet(key string, value []byte, timeout time.Duration) bool {
+	return list.core.TrySet(key, value, timeout)
 }
 
-func (list *SkipList) Back() *SkipListNode {
-	return list.tail.prevNode[0]
+func (list *SkipList) SetAndGet(key string, value []byte) ([]byte, bool) {
+	return list.core.SetAndGet(key, value)
 }
 
-func (list *SkipList) Set(key string, value []byte) {
-	node := list.findInternal(key, list.history)
-	if node != nil {
-		node.item.value = value
-		return
-	}
+func (list *SkipList) GetOrSet(key string, value []byte) ([]byte, bool) {
+	return list.core.GetOrSet(key, value)
+}
 
-	list.insertNode(key, value, list.history)
+func (list *SkipList) SetIfAbsent(key string, value []byte) bool {
+	return list.core.SetIfAbsent(key, value)
 }
 
-func (list *SkipList) Get(key string) *SkipListItem {
-	node := list.findInternal(key, list.history)
-	if node == nil {
-		return nil
-	}
-	return &node.item
+func (list *SkipList) SetBatch(items []SkipListItem) {
+	list.core.SetBatch(items)
+}
+
+// AppendSorted sets key when it is not smaller than the largest key in the
+// list. The new node is linked in front of the tail without searching, which
+// makes loading already sorted data cheaper than Set. A smaller key is
+// rejected with ErrOutOfOrder.
+func (list *SkipList) AppendSorted(key string, value []byte) error {
+	return list.core.AppendSorted(key, value)
 }
 
-func (list *SkipList) Remove(key string) {
-	node := list.findInternal(key, list.history)
-	if node == nil {
-		return
+// PutAll sets every entry of entries, overwriting existing keys.
+func (list *SkipList) PutAll(entries map[string][]byte) {
+	items := make([]SkipListItem, 0, len(entries))
+	for key, value := range entries {
+		items = append(items, NewItem(key, value))
 	}
+	list.SetBatch(items)
+}
 
-	list.deleteNode(node)
+// Update replaces the value of key with the result of fn, which receives a
+// copy of the current value. It returns false if key is absent.
+func (list *SkipList) Update(key string, fn func(old []byte) []byte) bool {
+	return list.core.Update(key, fn)
 }
 
-func (list *SkipList) findInternal(key string, history []*SkipListNode) *SkipListNode {
-	list.mutex.Lock()
-	defer list.mutex.Unlock()
+// ReplaceValue sets the value of key only if key is present, keeping its
+// expiry. It returns false without inserting when key is absent.
+func (list *SkipList) ReplaceValue(key string, value []byte) bool {
+	return list.core.ReplaceValue(key, value)
+}
 
-	current := list.head
-	for i := list.maxLevel - 1; i >= 0; i-- {
-		for list.tail != current.next(i) && current.next(i).item.key < key {
-			current = current.next(i)
+// ValueAppend appends data to the value stored under key, growing it in place
+// when its capacity allows. It returns false if key is absent.
+func (list *SkipList) ValueAppend(key string, data []byte) bool {
+	return list.core.Modify(key, func(value []byte) []byte {
+		if list.shared {
+			// the spare capacity of a shared value belongs to the caller
+			value = slices.Clip(value)
 		}
-		history[i] = current
-	}
+		return append(value, data...)
+	})
+}
 
-	current = current.next(0)
-	if current.isEndNode || !current.match(key) {
-		return nil
-	}
-	return current
+// CompareAndSwap replaces the value of key with new only if it currently
+// equals old. It returns false if key is absent or holds another value.
+func (list *SkipList) CompareAndSwap(key string, old, new []byte) bool {
+	return list.core.CompareAndSwapFunc(key, old, new, bytes.Equal)
 }
 
-func (list *SkipList) insertNode(key string, value []byte, history []*SkipListNode) {
-	randomLevel := list.randomLevel()
+// Swap exchanges the values of key1 and key2. The keys keep their positions
+// and expiry. It returns false if either key is absent.
+func (list *SkipList) Swap(key1, key2 string) bool {
+	return list.core.Swap(key1, key2)
+}
 
-	node := &SkipListNode{
-		levels:    randomLevel,
-		prevNode:  make([]*SkipListNode, randomLevel),
-		nextNode:  make([]*SkipListNode, randomLevel),
-		item:      SkipListItem{key: key, value: value},
-		isEndNode: false,
-	}
+// Get returns the stored item for key. The item points into the list, so
+// modifying its value changes the stored data; use Load for a safe copy.
+func (list *SkipList) Get(key string) *SkipListItem {
+	return list.core.Get(key)
+}
 
-	list.mutex.Lock()
-	defer list.mutex.Unlock()
+func (list *SkipList) Load(key string) ([]byte, bool) {
+	return list.core.Load(key)
+}
 
-	for i := 1; i <= randomLevel; i++ {
-		randomLevelIndex := i - 1
-		history[randomLevelIndex].appendOnLevel(node, randomLevelIndex)
-	}
+// GetMulti looks up all keys under one read lock and returns copies of their
+// values in the order of keys, with nil for missing keys. The keys are sorted
+// first so the list is walked forward only once.
+func (list *SkipList) GetMulti(keys []string) [][]byte {
+	return list.core.GetMulti(keys)
+}
+
+// FindWithSteps looks up key like Get and also returns the number of forward
+// hops the search made, which is useful for profiling slow lookups.
+func (list *SkipList) FindWithSteps(key string) (*SkipListItem, int) {
+	return list.core.FindWithSteps(key)
+}
 
-	list.length++
-	list.size += uint64(len(key))
-	list.size += uint64(len(value))
+// NodeLevel returns the height of the node holding key, which helps to
+// explain slow lookups. It returns false if key is absent.
+func (list *SkipList) NodeLevel(key string) (int, bool) {
+	return list.core.NodeLevel(key)
 }
 
-func (list *SkipList) deleteNode(node *SkipListNode) {
-	list.mutex.Lock()
-	defer list.mutex.Unlock()
+func (list *SkipList) Contains(key string) bool {
+	return list.core.Contains(key)
+}
 
-	list.size -= uint64(len(node.Key()))
-	list.size -= uint64(len(node.Value()))
+func (list *SkipList) Remove(key string) bool {
+	return list.core.Remove(key)
+}
 
-	for i := 0; i < node.nodeLevel(); i++ {
-		node.removeOnLevel(i)
-	}
+func (list *SkipList) PopMin() (*SkipListItem, bool) {
+	return list.core.PopMin()
+}
+
+func (list *SkipList) PopMax() (*SkipListItem, bool) {
+	return list.core.PopMax()
+}
+
+// RemoveFront removes and returns the first item. Like PopMin it unlinks the
+// node directly, without searching.
+func (list *SkipList) RemoveFront() (*SkipListItem, bool) {
+	return list.core.RemoveFront()
+}
+
+// RemoveBack removes and returns the last item. Like PopMax it unlinks the
+// node directly, without searching.
+func (list *SkipList) RemoveBack() (*SkipListItem, bool) {
+	return list.core.RemoveBack()
+}
+
+func (list *SkipList) Range(start, end string, fn func(node *SkipListNode) bool) {
+	list.core.Range(start, end, fn)
+}
+
+// EqualRange returns every node with key, in insertion order when the list
+// is a multiset.
+func (list *SkipList) EqualRange(key string) []*SkipListNode {
+	return list.core.EqualRange(key)
+}
+
+// ScanPrefix calls fn for every node whose key starts with prefix, stopping
+// early if fn returns false. It relies on lexicographic key order.
+func (list *SkipList) ScanPrefix(prefix string, fn func(node *SkipListNode) bool) {
+	list.core.RangeFrom(prefix, func(node *SkipListNode) bool {
+		return strings.HasPrefix(node.Key(), prefix) && fn(node)
+	})
+}
+
+// ForEach calls fn for every entry in key order with a copy of the value,
+// stopping early if fn returns false. fn must not modify the list.
+func (list *SkipList) ForEach(fn func(key string, value []byte) bool) {
+	list.core.ForEach(fn)
+}
+
+// RangeContext works like Range but checks ctx every 1024 nodes and returns
+// its error if the scan was cut short.
+func (list *SkipList) RangeContext(ctx context.Context, start, end string, fn func(node *SkipListNode) bool) error {
+	return list.core.RangeContext(ctx, start, end, fn)
+}
+
+// ForEachContext works like ForEach but checks ctx every 1024 nodes and
+// returns its error if the scan was cut short.
+func (list *SkipList) ForEachContext(ctx context.Context, fn func(key string, value []byte) bool) error {
+	return list.core.ForEachContext(ctx, fn)
+}
+
+func (list *SkipList) Ceil(key string) *SkipListNode {
+	return list.core.Ceil(key)
+}
+
+func (list *SkipList) Floor(key string) *SkipListNode {
+	return list.core.Floor(key)
+}
+
+// CeilItem returns the item with the smallest key >= the argument. exact is
+// true only when that key equals the argument.
+func (list *SkipList) CeilItem(key string) (*SkipListItem, bool) {
+	return list.core.CeilItem(key)
+}
+
+// FloorItem returns the item with the largest key <= the argument. exact is
+// true only when that key equals the argument.
+func (list *SkipList) FloorItem(key string) (*SkipListItem, bool) {
+	return list.core.FloorItem(key)
+}
 
-	list.length--
+// SeekForPrev returns the largest node with key <= the argument, so that the
+// list can be walked backward with Prev. It is equivalent to Floor.
+func (list *SkipList) SeekForPrev(key string) *SkipListNode {
+	return list.core.SeekForPrev(key)
 }
 
-func (list *SkipList) randomLevel() int {
-	const prob = 1 << 30
-	maxLevel := list.maxLevel
-	rand := list.rand
+func (list *SkipList) Rank(key string) (int, bool) {
+	return list.core.Rank(key)
+}
 
-	level := 1
-	for ; (level < maxLevel) && (rand.Int31() > prob); level++ {
+// CountRange returns the number of keys in [start, end). An empty end means
+// the range has no upper bound.
+func (list *SkipList) CountRange(start, end string) int {
+	if end == "" {
+		return list.core.CountFrom(start)
 	}
+	return list.core.CountRange(start, end)
+}
+
+// Select returns the node at zero based position n in key order, or nil if n
+// is negative or not less than the length.
+func (list *SkipList) Select(n int) *SkipListNode {
+	return list.core.Select(n)
+}
+
+// SelectItem works like Select but returns the item and ErrIndexOutOfRange
+// when n is negative or not less than the length.
+func (list *SkipList) SelectItem(n int) (*SkipListItem, error) {
+	return list.core.SelectItem(n)
+}
+
+// RandomNode returns a node chosen uniformly at random, or nil if the list is
+// empty. It picks a random position and finds it through the spans, so it
+// runs in O(log n). Positions come from the list's random source, so lists
+// made with the same Source sample the same way.
+func (list *SkipList) RandomNode() *SkipListNode {
+	return list.core.RandomNode()
+}
+
+// DeleteNth removes the item at zero based position n in key order and
+// returns it. It returns false if n is out of range.
+func (list *SkipList) DeleteNth(n int) (*SkipListItem, bool) {
+	return list.core.DeleteNth(n)
+}
+
+// DeleteRange removes every key in [start, end) and returns how many were removed.
+func (list *SkipList) DeleteRange(start, end string) int {
+	return list.core.DeleteRange(start, end)
+}
+
+// DeletePrefix removes every key that starts with prefix and returns how
+// many were removed. Like ScanPrefix it relies on lexicographic key order.
+func (list *SkipList) DeletePrefix(prefix string) int {
+	return list.core.DeleteWhile(prefix, func(key string) bool {
+		return strings.HasPrefix(key, prefix)
+	})
+}
+
+// Trim removes the largest keys until at most maxEntries remain and returns
+// the number of keys removed.
+func (list *SkipList) Trim(maxEntries int) int {
+	return list.core.Trim(maxEntries)
+}
+
+// Clear removes every entry. It only relinks the head to the tail and leaves
+// the removed nodes untouched, so an iterator standing on one of them still
+// walks forward to the tail and then stops.
+func (list *SkipList) Clear() {
+	list.core.Clear()
+}
+
+// Resize changes the maximum node height. Growing adds empty levels on top;
+// shrinking caps taller nodes at the new height. Values below 1 are raised to
+// 1. A Growable list is not shrunk below the height its length needs, since
+// it would grow back on the next insert.
+func (list *SkipList) Resize(newMaxLevel int) {
+	list.core.Resize(newMaxLevel)
+}
+
+// Compact rebuilds the list with freshly drawn node heights, capped to what
+// the current length needs. It keeps keys, values, expiry and recency.
+func (list *SkipList) Compact() {
+	list.core.Compact()
+}
+
+func (list *SkipList) Merge(other *SkipList) {
+	list.core.Merge(other.core)
+}
+
+// Equal reports whether both lists hold the same keys in the same order with
+// byte-equal values. Node heights and random sources are ignored.
+func (list *SkipList) Equal(other *SkipList) bool {
+	return list.core.EqualFunc(other.core, bytes.Equal)
+}
+
+func (list *SkipList) Clone() *SkipList {
+	return list.wrap(list.core.Clone())
+}
+
+// CopyRange returns an independent list holding copies of the entries in
+// [start, end), configured like this one.
+func (list *SkipList) CopyRange(start, end string) *SkipList {
+	return list.wrap(list.core.CopyRange(start, end))
+}
+
+// copyBytes never returns nil, so a stored nil value reads back as an empty
+// slice and a present key can't be mistaken for a missing one.
+func copyBytes(src []byte) []byte {
+	dst := make([]byte, len(src))
+	copy(dst, src)
+	return dst
+}
 
-	return level
+// shareBytes stores value itself for ShareValues, with the same nil rule as
+// copyBytes.
+func shareBytes(value []byte) []byte {
+	if value == nil {
+		return []byte{}
+	}
+	return value
 }
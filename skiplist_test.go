@@ -25,10 +25,16 @@ SOFTWARE.
 package skiplist
 
 import (
+	"context"
+	"fmt"
+	"math"
 	"math/rand"
+	"slices"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -49,52 +55,108 @@ func randomString(length int) string {
 	return string(b)
 }
 
-func TestOverLevelOnNode(t *testing.T) {
-	fistNode := &SkipListNode{
-		levels:    5,
-		prevNode:  make([]*SkipListNode, 5),
-		nextNode:  make([]*SkipListNode, 5),
-		item:      SkipListItem{key: "1", value: []byte("1")},
-		isEndNode: false,
+func nodeLevels(list *SkipList) []int {
+	var levels []int
+	for node := list.Front(); node != nil; node = node.Next() {
+		level, _ := list.NodeLevel(node.Key())
+		levels = append(levels, level)
 	}
+	return levels
+}
+
+func TestNew(t *testing.T) {
+	list := New(5)
+	assert.NotEqual(t, list, nil)
+}
+
+func TestMaxLevel(t *testing.T) {
+	list := New(5)
+	if assert.NotNil(t, list) {
+		assert.Equal(t, list.MaxLevel(), 5)
+	}
+}
+
+func TestNewWithRand(t *testing.T) {
+	first := NewWithRand(10, rand.NewSource(42))
+	second := NewWithRand(10, rand.NewSource(42))
 
-	secondNode := &SkipListNode{
-		levels:    3,
-		prevNode:  make([]*SkipListNode, 3),
-		nextNode:  make([]*SkipListNode, 3),
-		item:      SkipListItem{key: "2", value: []byte("2")},
-		isEndNode: false,
+	for i := 0; i < 1000; i++ {
+		key := strconv.Itoa(i)
+		first.Set(key, []byte(key))
+		second.Set(key, []byte(key))
 	}
 
-	fistNode.appendOnLevel(secondNode, 0)
-	fistNode.appendOnLevel(secondNode, 1)
-	fistNode.appendOnLevel(secondNode, 2)
+	assert.Equal(t, nodeLevels(first), nodeLevels(second))
+	assert.Equal(t, first.Keys(), second.Keys())
+
+	other := NewWithRand(10, rand.NewSource(7))
+	for i := 0; i < 1000; i++ {
+		key := strconv.Itoa(i)
+		other.Set(key, []byte(key))
+	}
+	assert.NotEqual(t, nodeLevels(first), nodeLevels(other))
+}
 
-	temp := fistNode.next(0)
-	assert.Equal(t, temp, secondNode)
+func TestLevelHistogram(t *testing.T) {
+	list := NewWithRand(8, rand.NewSource(42))
+	assert.Equal(t, list.LevelHistogram(), make([]int, 8))
 
-	temp = fistNode.next(1)
-	assert.Equal(t, temp, secondNode)
+	for i := 0; i < 1000; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
 
-	temp = fistNode.next(2)
-	assert.Equal(t, temp, secondNode)
+	histogram := list.LevelHistogram()
+	assert.Equal(t, len(histogram), 8)
 
-	temp = fistNode.next(3)
-	assert.Equal(t, temp, (*SkipListNode)(nil))
+	sum := 0
+	for _, count := range histogram {
+		sum += count
+	}
+	assert.Equal(t, sum, list.Length())
+	assert.Greater(t, histogram[0], histogram[1])
 
-	temp = fistNode.next(6)
-	assert.Equal(t, temp, (*SkipListNode)(nil))
+	expected := make([]int, 8)
+	for _, level := range nodeLevels(list) {
+		expected[level-1]++
+	}
+	assert.Equal(t, histogram, expected)
 }
 
-func TestNew(t *testing.T) {
-	list := New(5)
-	assert.NotEqual(t, list, nil)
+func TestApproxMemoryBytes(t *testing.T) {
+	short := NewWithRand(1, rand.NewSource(42))
+	tall := NewWithRand(16, rand.NewSource(42))
+	empty := tall.ApproxMemoryBytes()
+
+	for i := 0; i < 1000; i++ {
+		key := strconv.Itoa(i)
+		short.Set(key, []byte(key))
+		tall.Set(key, []byte(key))
+	}
+
+	assert.Greater(t, tall.ApproxMemoryBytes(), empty+tall.Size())
+	assert.Greater(t, short.ApproxMemoryBytes(), short.Size())
+	assert.Greater(t, tall.ApproxMemoryBytes(), short.ApproxMemoryBytes())
+
+	before := tall.ApproxMemoryBytes()
+	tall.Set("key", []byte("value"))
+	assert.Greater(t, tall.ApproxMemoryBytes(), before+uint64(len("keyvalue")))
 }
 
-func TestMaxLevel(t *testing.T) {
-	list := New(5)
-	if assert.NotNil(t, list) {
-		assert.Equal(t, list.MaxLevel(), 5)
+func TestInvalidMaxLevel(t *testing.T) {
+	for _, maxLevel := range []int{0, -3} {
+		list := New(maxLevel)
+		if assert.NotNil(t, list) {
+			assert.Equal(t, list.MaxLevel(), 1)
+
+			assert.Equal(t, list.Get("1"), (*SkipListItem)(nil))
+
+			list.Set("2", []byte("2"))
+			list.Set("1", []byte("1"))
+			assert.Equal(t, list.Get("1").Value(), []byte("1"))
+			assert.Equal(t, list.Front().Key(), "1")
+			assert.Equal(t, list.Back().Key(), "2")
+		}
 	}
 }
 
@@ -138,6 +200,229 @@ func TestGet(t *testing.T) {
 	assert.Equal(t, item_empty, (*SkipListItem)(nil))
 }
 
+func TestLoad(t *testing.T) {
+	list := New(5)
+
+	value, found := list.Load("1")
+	assert.False(t, found)
+	assert.Nil(t, value)
+
+	list.Set("1", []byte("value"))
+	value, found = list.Load("1")
+	assert.True(t, found)
+	assert.Equal(t, value, []byte("value"))
+
+	value[0] = 'X'
+	value, _ = list.Load("1")
+	assert.Equal(t, value, []byte("value"))
+}
+
+func TestGetMulti(t *testing.T) {
+	list := New(10)
+	for i := 0; i < 1000; i += 2 {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	assert.Equal(t, list.GetMulti(nil), [][]byte{})
+
+	keys := []string{"998", "1", "0", "500", "unknown", "0", "42", "43"}
+	values := list.GetMulti(keys)
+	assert.Equal(t, len(values), len(keys))
+	for i, key := range keys {
+		item := list.Get(key)
+		if item == nil {
+			assert.Nil(t, values[i], key)
+		} else {
+			assert.Equal(t, values[i], item.Value(), key)
+		}
+	}
+
+	values[0][0] = 'X'
+	assert.Equal(t, list.Get("998").Value(), []byte("998"))
+}
+
+func TestFindWithSteps(t *testing.T) {
+	levels := []int{1, 2, 1, 2, 1}
+	next := 0
+	list, err := NewWithOptions(Options{
+		MaxLevel: 2,
+		LevelFunc: func() int {
+			level := levels[next]
+			next++
+			return level
+		},
+	})
+	assert.Nil(t, err)
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		list.Set(key, []byte(key))
+	}
+
+	testCases := []struct {
+		key   string
+		found bool
+		steps int
+	}{
+		{key: "a", found: true, steps: 0},
+		{key: "c", found: true, steps: 1},
+		{key: "e", found: true, steps: 2},
+		{key: "bb", found: false, steps: 1},
+		{key: "z", found: false, steps: 3},
+	}
+
+	for _, testCase := range testCases {
+		item, steps := list.FindWithSteps(testCase.key)
+		assert.Equal(t, item != nil, testCase.found, testCase.key)
+		assert.Equal(t, steps, testCase.steps, testCase.key)
+	}
+
+	large := NewWithRand(20, rand.NewSource(1))
+	n := 100000
+	for i := 0; i < n; i++ {
+		key := strconv.Itoa(i)
+		large.Set(key, []byte(key))
+	}
+
+	total := 0
+	for i := 0; i < n; i += 97 {
+		item, steps := large.FindWithSteps(strconv.Itoa(i))
+		assert.NotNil(t, item)
+		total += steps
+	}
+	average := float64(total) / float64(n/97+1)
+	assert.Less(t, average, 3*math.Log2(float64(n)))
+}
+
+func TestNodeLevel(t *testing.T) {
+	levels := []int{2, 1, 4, 3}
+	next := 0
+	list, _ := NewWithOptions(Options{
+		MaxLevel: 4,
+		LevelFunc: func() int {
+			level := levels[next%len(levels)]
+			next++
+			return level
+		},
+	})
+	for _, key := range []string{"c", "a", "d", "b"} {
+		list.Set(key, []byte(key))
+	}
+
+	for key, expected := range map[string]int{"a": 1, "b": 3, "c": 2, "d": 4} {
+		level, found := list.NodeLevel(key)
+		assert.True(t, found)
+		assert.Equal(t, level, expected)
+	}
+
+	level, found := list.NodeLevel("e")
+	assert.False(t, found)
+	assert.Equal(t, level, 0)
+}
+
+func TestSetWithLevel(t *testing.T) {
+	list := New(4)
+	assert.Equal(t, list.SetWithLevel("a", nil, 0), ErrLevelOutOfRange)
+	assert.Equal(t, list.SetWithLevel("a", nil, 5), ErrLevelOutOfRange)
+	assert.Equal(t, list.Length(), 0)
+
+	for i, key := range []string{"d", "b", "a", "c", "e"} {
+		assert.Nil(t, list.SetWithLevel(key, []byte(key), i%4+1))
+	}
+	assert.Equal(t, nodeLevels(list), []int{3, 2, 4, 1, 1})
+	checkRanks(t, list)
+
+	assert.Nil(t, list.SetWithLevel("b", []byte("bb"), 2))
+	level, _ := list.NodeLevel("b")
+	assert.Equal(t, level, 2)
+
+	assert.Nil(t, list.SetWithLevel("a", []byte("aa"), 1))
+	assert.Nil(t, list.SetWithLevel("e", []byte("ee"), 4))
+	assert.Equal(t, nodeLevels(list), []int{1, 2, 4, 1, 4})
+	assert.Equal(t, list.Length(), 5)
+	assert.Equal(t, list.Size(), uint64(13))
+	checkRanks(t, list)
+
+	for _, key := range list.Keys() {
+		assert.True(t, list.Contains(key))
+	}
+	assert.Equal(t, list.Get("a").Value(), []byte("aa"))
+	assert.Equal(t, list.Get("b").Value(), []byte("bb"))
+	assert.Equal(t, list.Get("e").Value(), []byte("ee"))
+}
+
+func TestSetWithLevelMultisetAndHook(t *testing.T) {
+	list, _ := NewWithOptions(Options{MaxLevel: 4, Multiset: true})
+	removed := 0
+	list.OnRemove(func(key string, value []byte) { removed++ })
+
+	assert.Nil(t, list.SetWithLevel("a", []byte("1"), 3))
+	assert.Nil(t, list.SetWithLevel("a", []byte("2"), 1))
+	assert.Equal(t, list.Values(), [][]byte{[]byte("1"), []byte("2")})
+	assert.Equal(t, list.LevelHistogram(), []int{1, 0, 1, 0})
+	assert.Nil(t, list.Validate())
+
+	single := New(4)
+	single.OnRemove(func(key string, value []byte) { removed++ })
+	single.Set("a", []byte("1"))
+	assert.Nil(t, single.SetWithLevel("a", []byte("2"), 4))
+	assert.Equal(t, single.Get("a").Value(), []byte("2"))
+	assert.Equal(t, removed, 0)
+}
+
+func TestNodeLevelWithSeed(t *testing.T) {
+	first := NewWithRand(8, rand.NewSource(42))
+	second := NewWithRand(8, rand.NewSource(42))
+	for i := 0; i < 200; i++ {
+		key := strconv.Itoa(i)
+		first.Set(key, nil)
+		second.Set(key, nil)
+	}
+
+	heights := nodeLevels(first)
+	for i, key := range first.Keys() {
+		level, found := first.NodeLevel(key)
+		assert.True(t, found)
+		assert.Equal(t, level, heights[i])
+
+		level, _ = second.NodeLevel(key)
+		assert.Equal(t, level, heights[i])
+	}
+}
+
+func TestContains(t *testing.T) {
+	list := New(5)
+	assert.False(t, list.Contains("1"))
+
+	for i := 0; i < 5; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	assert.True(t, list.Contains("1"))
+	assert.True(t, list.Contains("4"))
+	assert.False(t, list.Contains("5"))
+	assert.False(t, list.Contains(""))
+
+	list.Remove("1")
+	assert.False(t, list.Contains("1"))
+}
+
+func TestSetNilValue(t *testing.T) {
+	list := New(5)
+	list.Set("k", nil)
+
+	assert.True(t, list.Contains("k"))
+	item := list.Get("k")
+	if assert.NotNil(t, item) {
+		assert.NotNil(t, item.Value())
+		assert.Equal(t, len(item.Value()), 0)
+	}
+
+	actual, _ := list.GetOrSet("other", nil)
+	assert.NotNil(t, actual)
+	assert.Equal(t, list.Size(), uint64(6))
+}
+
 func TestSize(t *testing.T) {
 	list := New(5)
 	assert.NotEqual(t, list, nil)
@@ -151,6 +436,50 @@ func TestSize(t *testing.T) {
 	assert.Equal(t, list.Size(), uint64(4))
 }
 
+func TestMaxKeyLenAndValueLen(t *testing.T) {
+	list := New(5)
+	assert.Equal(t, list.MaxKeyLen(), 0)
+	assert.Equal(t, list.MaxValueLen(), 0)
+
+	list.Set("a", []byte("12345"))
+	list.Set("abc", []byte("1"))
+	list.Set("ab", []byte("123"))
+	assert.Equal(t, list.MaxKeyLen(), 3)
+	assert.Equal(t, list.MaxValueLen(), 5)
+
+	list.Set("ab", []byte("1234567"))
+	assert.Equal(t, list.MaxValueLen(), 7)
+
+	list.Remove("abc")
+	list.Set("ab", []byte("1"))
+	assert.Equal(t, list.MaxKeyLen(), 3)
+	assert.Equal(t, list.MaxValueLen(), 7)
+
+	list.Compact()
+	assert.Equal(t, list.MaxKeyLen(), 2)
+	assert.Equal(t, list.MaxValueLen(), 5)
+
+	list.Clear()
+	assert.Equal(t, list.MaxKeyLen(), 0)
+	assert.Equal(t, list.MaxValueLen(), 0)
+}
+
+func TestSizeOnUpdate(t *testing.T) {
+	list := New(5)
+	list.Set("k", []byte("short"))
+	list.Set("other", []byte("value"))
+	assert.Equal(t, list.Size(), uint64(len("k")+len("short")+len("other")+len("value")))
+
+	list.Set("k", []byte("muchlongervalue"))
+	assert.Equal(t, list.Size(), uint64(len("k")+len("muchlongervalue")+len("other")+len("value")))
+
+	list.Set("k", []byte("s"))
+	assert.Equal(t, list.Size(), uint64(len("k")+len("s")+len("other")+len("value")))
+
+	list.Remove("k")
+	assert.Equal(t, list.Size(), uint64(len("other")+len("value")))
+}
+
 func TestUpdate(t *testing.T) {
 	list := New(5)
 	assert.NotEqual(t, list, nil)
@@ -167,134 +496,1895 @@ func TestUpdate(t *testing.T) {
 	assert.Equal(t, item_1.Value(), []byte("11"))
 }
 
-func TestRemove(t *testing.T) {
+func TestReplaceValue(t *testing.T) {
 	list := New(5)
-	assert.NotEqual(t, list, nil)
+	assert.False(t, list.ReplaceValue("1", []byte("a")))
+	assert.False(t, list.Contains("1"))
+	assert.Equal(t, list.Length(), 0)
+	assert.Equal(t, list.Size(), uint64(0))
+
+	list.Set("1", []byte("a"))
+	assert.True(t, list.ReplaceValue("1", []byte("abcd")))
+	assert.Equal(t, list.Get("1").Value(), []byte("abcd"))
+	assert.Equal(t, list.Size(), uint64(5))
+
+	assert.True(t, list.ReplaceValue("1", []byte("b")))
+	assert.Equal(t, list.Size(), uint64(2))
+	assert.Equal(t, list.Length(), 1)
+}
 
-	for i := 0; i < 5; i++ {
-		key := strconv.Itoa(i)
-		value := strconv.Itoa(i)
-		list.Set(key, []byte(value))
+func TestSwap(t *testing.T) {
+	list := New(5)
+	list.Set("a", []byte("1"))
+	list.Set("b", []byte("2222"))
+	list.Set("c", []byte("33"))
+
+	assert.True(t, list.Swap("a", "b"))
+	assert.Equal(t, list.Get("a").Value(), []byte("2222"))
+	assert.Equal(t, list.Get("b").Value(), []byte("1"))
+	assert.Equal(t, list.Keys(), []string{"a", "b", "c"})
+	assert.Equal(t, list.Size(), uint64(10))
+
+	assert.True(t, list.Swap("c", "c"))
+	assert.Equal(t, list.Get("c").Value(), []byte("33"))
+
+	assert.False(t, list.Swap("a", "x"))
+	assert.False(t, list.Swap("x", "a"))
+	assert.Equal(t, list.Get("a").Value(), []byte("2222"))
+	assert.False(t, list.Contains("x"))
+	assert.Nil(t, list.Validate())
+}
+
+func TestValueAppend(t *testing.T) {
+	list := New(5)
+	assert.False(t, list.ValueAppend("log", []byte("x")))
+	assert.False(t, list.Contains("log"))
+
+	list.Set("log", nil)
+	expected := ""
+	for i := 0; i < 100; i++ {
+		line := fmt.Sprintf("line %d\n", i)
+		assert.True(t, list.ValueAppend("log", []byte(line)))
+		expected += line
 	}
 
-	item_1 := list.Get("1")
-	assert.Equal(t, item_1.key, "1")
-	assert.Equal(t, item_1.value, []byte("1"))
+	assert.Equal(t, string(list.Get("log").Value()), expected)
+	assert.Equal(t, list.Size(), uint64(len("log")+len(expected)))
+	assert.Equal(t, list.MaxValueLen(), len(expected))
+	assert.Equal(t, list.Length(), 1)
+}
 
-	list.Remove("1")
-	item_temp := list.Get("1")
-	assert.Equal(t, item_temp, (*SkipListItem)(nil))
+func TestValueAppendShared(t *testing.T) {
+	list, _ := NewWithOptions(Options{MaxLevel: 5, ShareValues: true})
+	buffer := []byte("abcdef")
+	list.Set("1", buffer[:3])
 
-	list.Remove("1")
-	item_temp = list.Get("1")
-	assert.Equal(t, item_temp, (*SkipListItem)(nil))
+	assert.True(t, list.ValueAppend("1", []byte("XY")))
+	assert.Equal(t, list.Get("1").Value(), []byte("abcXY"))
+	assert.Equal(t, buffer, []byte("abcdef"))
 }
 
-func TestIterateNext(t *testing.T) {
+func TestCompareAndSwap(t *testing.T) {
 	list := New(5)
-	assert.NotEqual(t, list, nil)
+	assert.False(t, list.CompareAndSwap("1", nil, []byte("a")))
+	assert.False(t, list.Contains("1"))
 
-	var temp []string
-	for i := 0; i < 30; i++ {
-		word := randomString(5)
+	list.Set("1", []byte("a"))
+	assert.False(t, list.CompareAndSwap("1", []byte("b"), []byte("c")))
+	assert.Equal(t, list.Get("1").Value(), []byte("a"))
 
-		key := word
-		value := word
-		list.Set(key, []byte(value))
+	assert.True(t, list.CompareAndSwap("1", []byte("a"), []byte("abc")))
+	assert.Equal(t, list.Get("1").Value(), []byte("abc"))
+	assert.Equal(t, list.Size(), uint64(4))
+}
 
-		temp = append(temp, word)
+func TestConcurrentCompareAndSwap(t *testing.T) {
+	list := New(5)
+	list.Set("key", []byte("0"))
+
+	var wg sync.WaitGroup
+	var winners atomic.Int32
+	for w := 1; w <= 50; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			if list.CompareAndSwap("key", []byte("0"), []byte(strconv.Itoa(w))) {
+				winners.Add(1)
+			}
+		}(w)
+	}
+	wg.Wait()
+	assert.Equal(t, winners.Load(), int32(1))
+
+	list.Set("counter", []byte("0"))
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				for {
+					old, _ := list.Load("counter")
+					n, _ := strconv.Atoi(string(old))
+					if list.CompareAndSwap("counter", old, []byte(strconv.Itoa(n+1))) {
+						break
+					}
+				}
+			}
+		}()
 	}
+	wg.Wait()
+	assert.Equal(t, list.Get("counter").Value(), []byte("800"))
+}
 
-	sort.Strings(temp)
+func TestTrySet(t *testing.T) {
+	list := New(5)
+	assert.True(t, list.TrySet("a", []byte("a"), 0))
+	assert.Equal(t, list.Get("a").Value(), []byte("a"))
 
-	node := list.Front()
-	for _, word := range temp {
-		if assert.NotNil(t, node) {
-			assert.Equal(t, node.Key(), word)
-			assert.Equal(t, node.Value(), []byte(word))
+	list.WithReadLock(func(view ReadView) {
+		start := time.Now()
+		assert.False(t, list.TrySet("b", []byte("b"), 20*time.Millisecond))
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	})
+
+	locked := make(chan struct{})
+	released := make(chan struct{})
+	go func() {
+		list.WithWriteLock(func(view WriteView) {
+			close(locked)
+			time.Sleep(10 * time.Millisecond)
+		})
+		close(released)
+	}()
+	<-locked
+	assert.True(t, list.TrySet("b", []byte("b"), time.Second))
+	<-released
+
+	assert.Equal(t, list.Keys(), []string{"a", "b"})
+}
+
+func TestSetBatch(t *testing.T) {
+	list := New(10)
+	expected := New(10)
+
+	var items []SkipListItem
+	for _, i := range rand.Perm(1000) {
+		key := strconv.Itoa(i % 700)
+		value := strconv.Itoa(i)
+		items = append(items, NewItem(key, []byte(value)))
+		expected.Set(key, []byte(value))
+	}
+
+	list.Set("5", []byte("old"))
+	list.SetBatch(items)
+
+	assert.Equal(t, list.Length(), 700)
+	assert.Equal(t, list.Size(), expected.Size())
+	assert.Equal(t, list.Keys(), expected.Keys())
+	assert.Equal(t, list.Values(), expected.Values())
+
+	for i, key := range list.Keys() {
+		rank, found := list.Rank(key)
+		if assert.True(t, found) {
+			assert.Equal(t, rank, i)
 		}
-		node = node.Next()
 	}
 }
 
-func TestIteratePrev(t *testing.T) {
+func TestSetAndGetPrevious(t *testing.T) {
 	list := New(5)
 	assert.NotEqual(t, list, nil)
 
-	var temp []string
-	for i := 0; i < 30; i++ {
-		word := randomString(5)
+	old, existed := list.SetAndGet("1", []byte("1"))
+	assert.False(t, existed)
+	assert.Nil(t, old)
 
-		key := word
-		value := word
-		list.Set(key, []byte(value))
+	old, existed = list.SetAndGet("1", []byte("11"))
+	assert.True(t, existed)
+	assert.Equal(t, old, []byte("1"))
+	assert.Equal(t, list.Get("1").Value(), []byte("11"))
+	assert.Equal(t, list.Length(), 1)
+}
 
-		temp = append(temp, word)
-	}
+func TestGetOrSet(t *testing.T) {
+	list := New(5)
+	assert.NotEqual(t, list, nil)
 
-	sort.Strings(temp)
+	actual, loaded := list.GetOrSet("1", []byte("1"))
+	assert.False(t, loaded)
+	assert.Equal(t, actual, []byte("1"))
 
-	node := list.Back()
-	for i := range temp {
-		word := temp[len(temp)-1-i]
-		if assert.NotNil(t, node) {
-			assert.Equal(t, node.Key(), word)
-			assert.Equal(t, node.Value(), []byte(word))
-		}
-		node = node.Prev()
-	}
+	actual, loaded = list.GetOrSet("1", []byte("11"))
+	assert.True(t, loaded)
+	assert.Equal(t, actual, []byte("1"))
+	assert.Equal(t, list.Get("1").Value(), []byte("1"))
 }
 
-func TestConcurrency(t *testing.T) {
+func TestConcurrentGetOrSet(t *testing.T) {
 	list := New(10)
 
+	const workers = 32
+	results := make([][]byte, workers)
+	loadedCount := 0
+
+	mutex := sync.Mutex{}
 	wg := &sync.WaitGroup{}
-	wg.Add(2)
+	wg.Add(workers)
+
+	for w := 0; w < workers; w++ {
+		go func(worker int) {
+			actual, loaded := list.GetOrSet("key", []byte(strconv.Itoa(worker)))
+			results[worker] = actual
+
+			if loaded {
+				mutex.Lock()
+				loadedCount++
+				mutex.Unlock()
+			}
+			wg.Done()
+		}(w)
+	}
 
-	go func() {
-		for i := 0; i < 100000; i++ {
-			key := strconv.Itoa(i)
-			value := strconv.Itoa(i)
-			list.Set(key, []byte(value))
-		}
-		wg.Done()
-	}()
+	wg.Wait()
+	assert.Equal(t, loadedCount, workers-1)
+	for _, result := range results {
+		assert.Equal(t, result, results[0])
+	}
+	assert.Equal(t, list.Get("key").Value(), results[0])
+}
 
-	go func() {
-		for i := 0; i < 100000; i++ {
-			key := strconv.Itoa(i)
-			list.Get(key)
-		}
-		wg.Done()
-	}()
+func TestSetCopiesValue(t *testing.T) {
+	list := New(5)
+	assert.NotEqual(t, list, nil)
+
+	buffer := []byte("value")
+	list.Set("1", buffer)
+	buffer[0] = 'X'
+	assert.Equal(t, list.Get("1").Value(), []byte("value"))
+
+	buffer = []byte("other")
+	list.Set("1", buffer)
+	buffer[0] = 'X'
+	assert.Equal(t, list.Get("1").Value(), []byte("other"))
+	assert.Equal(t, list.Size(), uint64(6))
+}
+
+func TestSetIfAbsent(t *testing.T) {
+	list := New(5)
+
+	assert.True(t, list.SetIfAbsent("1", []byte("1")))
+	assert.False(t, list.SetIfAbsent("1", []byte("11")))
+	assert.Equal(t, list.Get("1").Value(), []byte("1"))
+	assert.Equal(t, list.Length(), 1)
+}
+
+func TestConcurrentSetIfAbsent(t *testing.T) {
+	list := New(10)
+
+	const workers = 32
+	var succeeded int32
+
+	mutex := sync.Mutex{}
+	wg := &sync.WaitGroup{}
+	wg.Add(workers)
+
+	for w := 0; w < workers; w++ {
+		go func(worker int) {
+			if list.SetIfAbsent("key", []byte(strconv.Itoa(worker))) {
+				mutex.Lock()
+				succeeded++
+				mutex.Unlock()
+			}
+			wg.Done()
+		}(w)
+	}
 
 	wg.Wait()
-	assert.Equal(t, list.Length(), 100000)
+	assert.Equal(t, succeeded, int32(1))
+	assert.Equal(t, list.Length(), 1)
 }
 
-var benchList *SkipList
+func TestUpdateFunc(t *testing.T) {
+	list := New(5)
 
-func BenchmarkSet(b *testing.B) {
-	b.ReportAllocs()
+	assert.False(t, list.Update("1", func(old []byte) []byte {
+		t.Fail()
+		return old
+	}))
+	assert.False(t, list.Contains("1"))
+
+	list.Set("1", []byte("a"))
+	assert.True(t, list.Update("1", func(old []byte) []byte {
+		assert.Equal(t, old, []byte("a"))
+		old[0] = 'X'
+		return []byte("abc")
+	}))
+	assert.Equal(t, list.Get("1").Value(), []byte("abc"))
+	assert.Equal(t, list.Size(), uint64(4))
+}
 
-	benchList = New(15)
+func TestConcurrentUpdate(t *testing.T) {
+	list := New(10)
+	list.Set("counter", []byte("0"))
 
-	for i := 0; i < b.N; i++ {
-		key := strconv.Itoa(i)
-		value := strconv.Itoa(i)
-		benchList.Set(key, []byte(value))
+	const workers = 16
+	const increments = 100
+
+	wg := &sync.WaitGroup{}
+	wg.Add(workers)
+
+	for w := 0; w < workers; w++ {
+		go func() {
+			for i := 0; i < increments; i++ {
+				list.Update("counter", func(old []byte) []byte {
+					count, _ := strconv.Atoi(string(old))
+					return []byte(strconv.Itoa(count + 1))
+				})
+			}
+			wg.Done()
+		}()
 	}
 
-	b.SetBytes(int64(b.N))
+	wg.Wait()
+	assert.Equal(t, list.Get("counter").Value(), []byte(strconv.Itoa(workers*increments)))
+	assert.Equal(t, list.Size(), uint64(len("counter")+len(strconv.Itoa(workers*increments))))
 }
 
-func BenchmarkGet(b *testing.B) {
-	b.ReportAllocs()
+func TestRemove(t *testing.T) {
+	list := New(5)
+	assert.NotEqual(t, list, nil)
 
-	for i := 0; i < b.N; i++ {
+	for i := 0; i < 5; i++ {
 		key := strconv.Itoa(i)
-		benchList.Get(key)
+		value := strconv.Itoa(i)
+		list.Set(key, []byte(value))
+	}
+
+	item_1 := list.Get("1")
+	assert.Equal(t, item_1.Key(), "1")
+	assert.Equal(t, item_1.Value(), []byte("1"))
+
+	list.Remove("1")
+	item_temp := list.Get("1")
+	assert.Equal(t, item_temp, (*SkipListItem)(nil))
+
+	list.Remove("1")
+	item_temp = list.Get("1")
+	assert.Equal(t, item_temp, (*SkipListItem)(nil))
+}
+
+func checkRanks(t *testing.T, list *SkipList) {
+	assert.Nil(t, list.Validate())
+	for i, key := range list.Keys() {
+		rank, found := list.Rank(key)
+		if assert.True(t, found) {
+			assert.Equal(t, rank, i)
+		}
+		assert.Equal(t, list.Select(i).Key(), key)
+	}
+}
+
+func TestRandomNode(t *testing.T) {
+	list := NewWithRand(5, rand.NewSource(42))
+	assert.Nil(t, list.RandomNode())
+
+	const keys, samples = 10, 20000
+	for i := 0; i < keys; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	counts := map[string]int{}
+	for i := 0; i < samples; i++ {
+		node := list.RandomNode()
+		assert.Equal(t, node.Value(), []byte(node.Key()))
+		counts[node.Key()]++
+	}
+
+	// each key is expected samples/keys times with a deviation of about 42
+	assert.Equal(t, len(counts), keys)
+	for _, count := range counts {
+		assert.InDelta(t, count, samples/keys, 400)
+	}
+}
+
+func TestRandomNodeWithSource(t *testing.T) {
+	sample := func() []string {
+		list := NewWithRand(5, rand.NewSource(7))
+		for i := 0; i < 100; i++ {
+			key := strconv.Itoa(i)
+			list.Set(key, []byte(key))
+		}
+
+		keys := []string{}
+		for i := 0; i < 20; i++ {
+			keys = append(keys, list.RandomNode().Key())
+		}
+		return keys
+	}
+	assert.Equal(t, sample(), sample())
+
+	list := NewWithRand(5, rand.NewSource(7))
+	list.Set("a", nil)
+	wg := &sync.WaitGroup{}
+	wg.Add(4)
+	for r := 0; r < 4; r++ {
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				assert.Equal(t, list.RandomNode().Key(), "a")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestDeleteNth(t *testing.T) {
+	list := newListWithKeys("a", "b", "c", "d", "e", "f", "g")
+
+	item, ok := list.DeleteNth(0)
+	assert.True(t, ok)
+	assert.Equal(t, item.Key(), "a")
+	assert.Equal(t, list.Keys(), []string{"b", "c", "d", "e", "f", "g"})
+
+	item, ok = list.DeleteNth(list.Length() - 1)
+	assert.True(t, ok)
+	assert.Equal(t, item.Key(), "g")
+	assert.Equal(t, list.Keys(), []string{"b", "c", "d", "e", "f"})
+
+	item, ok = list.DeleteNth(list.Length() / 2)
+	assert.True(t, ok)
+	assert.Equal(t, item.Key(), "d")
+	assert.Equal(t, item.Value(), []byte("d"))
+	assert.Equal(t, list.Keys(), []string{"b", "c", "e", "f"})
+	assert.Equal(t, list.Size(), uint64(8))
+	checkRanks(t, list)
+
+	for _, n := range []int{-1, 4, 100} {
+		item, ok = list.DeleteNth(n)
+		assert.False(t, ok)
+		assert.Nil(t, item)
+	}
+	assert.Equal(t, list.Length(), 4)
+}
+
+func TestDeleteRange(t *testing.T) {
+	list := New(5)
+	assert.Equal(t, list.DeleteRange("a", "z"), 0)
+
+	var keys []string
+	for i := 10; i < 100; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+		keys = append(keys, key)
+	}
+
+	assert.Equal(t, list.DeleteRange("5", "3"), 0)
+	assert.Equal(t, list.DeleteRange("3", "3"), 0)
+	assert.Equal(t, list.DeleteRange("0", "1"), 0)
+	assert.Equal(t, list.DeleteRange("a", "z"), 0)
+	assert.Equal(t, list.Keys(), keys)
+
+	assert.Equal(t, list.DeleteRange("20", "30"), 10)
+	assert.Equal(t, list.Length(), 80)
+	assert.Equal(t, list.Size(), uint64(80*4))
+	assert.False(t, list.Contains("25"))
+	assert.True(t, list.Contains("19"))
+	assert.True(t, list.Contains("30"))
+	checkRanks(t, list)
+
+	assert.Equal(t, list.DeleteRange("15", "35"), 10)
+	assert.Equal(t, list.Keys()[:6], []string{"10", "11", "12", "13", "14", "35"})
+	checkRanks(t, list)
+
+	list.Set("50", []byte("50"))
+	assert.Equal(t, list.Get("50").Value(), []byte("50"))
+	checkRanks(t, list)
+
+	assert.Equal(t, list.DeleteRange("", "~"), 70)
+	assert.Equal(t, list.Length(), 0)
+	assert.Equal(t, list.Size(), uint64(0))
+	assert.Equal(t, len(list.Keys()), 0)
+
+	list.Set("1", []byte("1"))
+	assert.Equal(t, list.Keys(), []string{"1"})
+	checkRanks(t, list)
+}
+
+func TestCompact(t *testing.T) {
+	list := NewWithRand(20, rand.NewSource(42))
+	for i := 0; i < 100000; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	for _, i := range rand.Perm(100000)[:90000] {
+		list.Remove(strconv.Itoa(i))
+	}
+
+	keys := list.Keys()
+	values := list.Values()
+	size := list.Size()
+
+	list.Compact()
+	assert.Equal(t, list.Length(), 10000)
+	assert.Equal(t, list.Size(), size)
+	assert.Equal(t, list.Keys(), keys)
+	assert.Equal(t, list.Values(), values)
+	checkRanks(t, list)
+
+	histogram := list.LevelHistogram()
+	sum := 0
+	for level, count := range histogram {
+		if level >= 14 {
+			assert.Equal(t, count, 0)
+		}
+		sum += count
+	}
+	assert.Equal(t, sum, 10000)
+
+	for _, key := range keys[:100] {
+		assert.Equal(t, list.Get(key).Value(), []byte(key))
+	}
+
+	list.Set("new", []byte("new"))
+	list.Remove(keys[0])
+	assert.Equal(t, list.Length(), 10000)
+	checkRanks(t, list)
+}
+
+func TestClear(t *testing.T) {
+	list := New(5)
+	assert.NotEqual(t, list, nil)
+
+	for i := 0; i < 100; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	list.Clear()
+	assert.Equal(t, list.Length(), 0)
+	assert.Equal(t, list.Size(), uint64(0))
+	assert.Equal(t, list.Get("1"), (*SkipListItem)(nil))
+	assert.Equal(t, len(list.Keys()), 0)
+
+	for i := 0; i < 10; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	assert.Equal(t, list.Length(), 10)
+	assert.Equal(t, list.Size(), uint64(20))
+	assert.Equal(t, list.Get("1").Value(), []byte("1"))
+	assert.Equal(t, list.Front().Key(), "0")
+	assert.Equal(t, list.Back().Key(), "9")
+}
+
+func TestRemoveResult(t *testing.T) {
+	list := New(5)
+	assert.NotEqual(t, list, nil)
+
+	assert.False(t, list.Remove("1"))
+
+	for i := 0; i < 5; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	assert.True(t, list.Remove("1"))
+	assert.False(t, list.Remove("1"))
+	assert.False(t, list.Remove("5"))
+	assert.True(t, list.Remove("4"))
+	assert.Equal(t, list.Length(), 3)
+}
+
+func TestClone(t *testing.T) {
+	list := New(5)
+	for _, i := range rand.Perm(100) {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	clone := list.Clone()
+	assert.Equal(t, clone.MaxLevel(), list.MaxLevel())
+	assert.Equal(t, clone.Length(), list.Length())
+	assert.Equal(t, clone.Size(), list.Size())
+	assert.Equal(t, clone.Keys(), list.Keys())
+	assert.Equal(t, clone.Values(), list.Values())
+
+	for i := 0; i < 100; i++ {
+		rank, found := clone.Rank(strconv.Itoa(i))
+		if assert.True(t, found) {
+			assert.Equal(t, clone.Select(rank).Key(), strconv.Itoa(i))
+		}
+	}
+
+	clone.Set("1", []byte("clone"))
+	clone.Remove("2")
+	clone.Set("100", []byte("100"))
+	list.Set("3", []byte("list"))
+	list.Remove("4")
+
+	assert.Equal(t, list.Get("1").Value(), []byte("1"))
+	assert.NotNil(t, list.Get("2"))
+	assert.Nil(t, list.Get("100"))
+	assert.Equal(t, clone.Get("3").Value(), []byte("3"))
+	assert.NotNil(t, clone.Get("4"))
+
+	clone.Get("5").Value()[0] = 'X'
+	assert.Equal(t, list.Get("5").Value(), []byte("5"))
+}
+
+func newListWithKeys(keys ...string) *SkipList {
+	list := New(5)
+	for _, key := range keys {
+		list.Set(key, []byte(key))
+	}
+	return list
+}
+
+func TestCopyRange(t *testing.T) {
+	list := New(10)
+	for i := 10; i < 100; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	sub := list.CopyRange("20", "30")
+	var expected []string
+	for i := 20; i < 30; i++ {
+		expected = append(expected, strconv.Itoa(i))
+	}
+	assert.Equal(t, sub.Keys(), expected)
+	assert.Equal(t, sub.Length(), 10)
+	assert.Equal(t, sub.Size(), uint64(40))
+	checkRanks(t, sub)
+
+	sub.Set("25", []byte("changed"))
+	sub.Remove("20")
+	sub.Set("99a", []byte("new"))
+	assert.Equal(t, list.Get("25").Value(), []byte("25"))
+	assert.True(t, list.Contains("20"))
+	assert.False(t, list.Contains("99a"))
+	assert.Equal(t, list.Length(), 90)
+
+	list.Remove("21")
+	assert.True(t, sub.Contains("21"))
+
+	assert.Equal(t, list.CopyRange("30", "20").Length(), 0)
+	assert.Equal(t, list.CopyRange("a", "z").Length(), 0)
+	assert.Equal(t, list.CopyRange("", "~").Length(), 89)
+}
+
+func TestResize(t *testing.T) {
+	list := NewWithRand(4, rand.NewSource(3))
+	for i := 0; i < 1000; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+	keys := list.Keys()
+
+	list.Resize(16)
+	assert.Equal(t, list.MaxLevel(), 16)
+	assert.Equal(t, list.Keys(), keys)
+	checkRanks(t, list)
+
+	for i := 1000; i < 5000; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+	assert.Greater(t, slices.Max(nodeLevels(list)), 4)
+	checkRanks(t, list)
+
+	list.Resize(4)
+	assert.Equal(t, list.MaxLevel(), 4)
+	assert.Equal(t, list.Length(), 5000)
+	assert.LessOrEqual(t, slices.Max(nodeLevels(list)), 4)
+	assert.Equal(t, len(list.LevelHistogram()), 4)
+	checkRanks(t, list)
+
+	for i := 0; i < 5000; i += 7 {
+		key := strconv.Itoa(i)
+		assert.Equal(t, list.Get(key).Value(), []byte(key))
+	}
+
+	list.Remove("42")
+	list.Set("new", []byte("new"))
+	checkRanks(t, list)
+
+	list.Resize(0)
+	assert.Equal(t, list.MaxLevel(), 1)
+	assert.Equal(t, list.Get("new").Value(), []byte("new"))
+	checkRanks(t, list)
+}
+
+func TestResizeGrowable(t *testing.T) {
+	list, _ := NewWithOptions(Options{MaxLevel: 1, Growable: true})
+	for i := 0; i < 5; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+	assert.Equal(t, list.MaxLevel(), 3)
+
+	// five entries need three levels before the list would grow again
+	list.Resize(1)
+	assert.Equal(t, list.MaxLevel(), 3)
+	list.Compact()
+	assert.Nil(t, list.Validate())
+	assert.Equal(t, list.Keys(), []string{"0", "1", "2", "3", "4"})
+
+	list.Resize(6)
+	assert.Equal(t, list.MaxLevel(), 6)
+	list.Resize(2)
+	assert.Equal(t, list.MaxLevel(), 3)
+	assert.Nil(t, list.Validate())
+}
+
+func TestMerge(t *testing.T) {
+	list := newListWithKeys("a", "c")
+	other := newListWithKeys("b", "d")
+
+	list.Merge(other)
+	assert.Equal(t, list.Keys(), []string{"a", "b", "c", "d"})
+	assert.Equal(t, list.Length(), 4)
+	assert.Equal(t, list.Size(), uint64(8))
+	assert.Equal(t, other.Keys(), []string{"b", "d"})
+
+	list = newListWithKeys("a", "b")
+	other = New(5)
+	other.Set("a", []byte("other-a"))
+	other.Set("b", []byte("other-b"))
+
+	list.Merge(other)
+	assert.Equal(t, list.Keys(), []string{"a", "b"})
+	assert.Equal(t, list.Values(), [][]byte{[]byte("other-a"), []byte("other-b")})
+	assert.Equal(t, list.Size(), other.Size())
+
+	list = newListWithKeys("a", "b", "c")
+	other = New(5)
+	other.Set("c", []byte("cc"))
+	other.Set("d", []byte("dd"))
+
+	list.Merge(other)
+	assert.Equal(t, list.Keys(), []string{"a", "b", "c", "d"})
+	assert.Equal(t, list.Get("c").Value(), []byte("cc"))
+	assert.Equal(t, list.Length(), 4)
+	assert.Equal(t, other.Length(), 2)
+}
+
+func TestKeysPage(t *testing.T) {
+	list := New(10)
+	assert.Equal(t, list.KeysPage("", 10), []string{})
+
+	for i := 0; i < 100; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	for _, limit := range []int{1, 3, 7, 100, 1000} {
+		var keys []string
+		after := ""
+		for {
+			page := list.KeysPage(after, limit)
+			assert.LessOrEqual(t, len(page), limit)
+			if len(page) == 0 {
+				break
+			}
+			keys = append(keys, page...)
+			after = page[len(page)-1]
+		}
+		assert.Equal(t, keys, list.Keys(), limit)
+	}
+
+	assert.Equal(t, list.KeysPage("5", 3), []string{"50", "51", "52"})
+	assert.Equal(t, list.KeysPage("55a", 2), []string{"56", "57"})
+	assert.Equal(t, list.KeysPage("99", 10), []string{})
+	assert.Equal(t, list.KeysPage("", 0), []string{})
+	assert.Equal(t, list.KeysPage("", -1), []string{})
+}
+
+func TestAppendSorted(t *testing.T) {
+	list := New(6)
+	for i := 0; i < 500; i++ {
+		key := fmt.Sprintf("%04d", i)
+		assert.Nil(t, list.AppendSorted(key, []byte(key)))
+	}
+	assert.Equal(t, list.Length(), 500)
+	assert.Equal(t, list.Front().Key(), "0000")
+	assert.Equal(t, list.Back().Key(), "0499")
+	checkRanks(t, list)
+
+	assert.Equal(t, list.AppendSorted("0498", []byte("late")), ErrOutOfOrder)
+	assert.Equal(t, list.Get("0498").Value(), []byte("0498"))
+	assert.Equal(t, list.Length(), 500)
+
+	assert.Nil(t, list.AppendSorted("0499", []byte("again")))
+	assert.Equal(t, list.Length(), 500)
+	assert.Equal(t, list.Get("0499").Value(), []byte("again"))
+
+	list.Remove("0499")
+	assert.Nil(t, list.AppendSorted("0498x", nil))
+	assert.Equal(t, list.Back().Key(), "0498x")
+	checkRanks(t, list)
+}
+
+func TestAppendSortedMultiset(t *testing.T) {
+	list, _ := NewWithOptions(Options{MaxLevel: 4, Multiset: true})
+	assert.Nil(t, list.AppendSorted("a", []byte("1")))
+	assert.Nil(t, list.AppendSorted("a", []byte("2")))
+	assert.Nil(t, list.AppendSorted("b", []byte("3")))
+	assert.Equal(t, list.AppendSorted("a", []byte("4")), ErrOutOfOrder)
+
+	assert.Equal(t, list.Length(), 3)
+	assert.Equal(t, list.Values(), [][]byte{[]byte("1"), []byte("2"), []byte("3")})
+	assert.Nil(t, list.Validate())
+}
+
+func TestPutAllAndToMap(t *testing.T) {
+	list := New(5)
+	assert.Equal(t, list.ToMap(), map[string][]byte{})
+
+	entries := map[string][]byte{}
+	for i := 0; i < 100; i++ {
+		key := strconv.Itoa(i)
+		entries[key] = []byte("value-" + key)
+	}
+
+	list.PutAll(entries)
+	assert.Equal(t, list.Length(), 100)
+	assert.Equal(t, list.ToMap(), entries)
+	checkRanks(t, list)
+
+	list.PutAll(map[string][]byte{"0": []byte("changed"), "new": []byte("new")})
+	assert.Equal(t, list.Length(), 101)
+	assert.Equal(t, list.Get("0").Value(), []byte("changed"))
+
+	exported := list.ToMap()
+	exported["new"][0] = 'N'
+	assert.Equal(t, list.Get("new").Value(), []byte("new"))
+}
+
+func TestEqual(t *testing.T) {
+	list := newListWithKeys("a", "b", "c", "d")
+	other := NewWithRand(10, rand.NewSource(7))
+	for _, key := range []string{"d", "b", "a", "c"} {
+		other.Set(key, []byte(key))
+	}
+
+	assert.True(t, list.Equal(other))
+	assert.True(t, other.Equal(list))
+	assert.True(t, list.Equal(list))
+	assert.True(t, New(5).Equal(New(10)))
+
+	other.Set("c", []byte("changed"))
+	assert.False(t, list.Equal(other))
+	assert.False(t, other.Equal(list))
+
+	other.Set("c", []byte("c"))
+	assert.True(t, list.Equal(other))
+
+	other.Set("e", []byte("e"))
+	assert.False(t, list.Equal(other))
+	assert.False(t, other.Equal(list))
+
+	other.Remove("e")
+	other.Remove("a")
+	other.Set("z", []byte("a"))
+	assert.False(t, list.Equal(other))
+}
+
+func TestRemoveFrontAndBack(t *testing.T) {
+	list := newListWithKeys("a", "b", "c", "d", "e")
+
+	var removed []string
+	for i := 0; !list.IsEmpty(); i++ {
+		var item *SkipListItem
+		var ok bool
+		if i%2 == 0 {
+			item, ok = list.RemoveFront()
+		} else {
+			item, ok = list.RemoveBack()
+		}
+		assert.True(t, ok)
+		removed = append(removed, item.Key())
+		checkRanks(t, list)
+	}
+
+	assert.Equal(t, removed, []string{"a", "e", "b", "d", "c"})
+	assert.Equal(t, list.Length(), 0)
+	assert.Equal(t, list.Size(), uint64(0))
+
+	item, ok := list.RemoveFront()
+	assert.Nil(t, item)
+	assert.False(t, ok)
+
+	item, ok = list.RemoveBack()
+	assert.Nil(t, item)
+	assert.False(t, ok)
+}
+
+func TestTrim(t *testing.T) {
+	list := New(10)
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("%03d", i)
+		list.Set(key, []byte(key))
+	}
+
+	assert.Equal(t, list.Trim(10), 90)
+	assert.Equal(t, list.Length(), 10)
+	assert.Equal(t, list.Size(), uint64(60))
+
+	var expected []string
+	for i := 0; i < 10; i++ {
+		expected = append(expected, fmt.Sprintf("%03d", i))
+	}
+	assert.Equal(t, list.Keys(), expected)
+	assert.Equal(t, list.Back().Key(), "009")
+	checkRanks(t, list)
+
+	assert.Equal(t, list.Trim(10), 0)
+	assert.Equal(t, list.Trim(-1), 10)
+	assert.True(t, list.IsEmpty())
+}
+
+func TestPopMinAndPopMax(t *testing.T) {
+	list := New(5)
+
+	item, ok := list.PopMin()
+	assert.False(t, ok)
+	assert.Equal(t, item, (*SkipListItem)(nil))
+
+	item, ok = list.PopMax()
+	assert.False(t, ok)
+	assert.Equal(t, item, (*SkipListItem)(nil))
+
+	for i := 0; i < 50; i++ {
+		word := randomString(5)
+		list.Set(word, []byte(word))
+	}
+
+	length := list.Length()
+	prev := ""
+	for i := 0; i < length/2; i++ {
+		item, ok := list.PopMin()
+		if assert.True(t, ok) {
+			assert.Less(t, prev, item.Key())
+			assert.Equal(t, item.Value(), []byte(item.Key()))
+			assert.False(t, list.Contains(item.Key()))
+			prev = item.Key()
+		}
+	}
+
+	prev = "~"
+	for list.Length() > 0 {
+		item, ok := list.PopMax()
+		if assert.True(t, ok) {
+			assert.Greater(t, prev, item.Key())
+			prev = item.Key()
+		}
+	}
+
+	_, ok = list.PopMin()
+	assert.False(t, ok)
+	assert.Equal(t, list.Size(), uint64(0))
+}
+
+func TestString(t *testing.T) {
+	list := New(3)
+	assert.Equal(t, list.String(), "level 2:\nlevel 1:\nlevel 0:\n")
+
+	for i := 0; i < 20; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	output := list.String()
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	assert.Equal(t, len(lines), 3)
+	assert.Equal(t, lines[2], "level 0: "+strings.Join(list.Keys(), " "))
+	for i := 0; i < 20; i++ {
+		assert.Contains(t, output, " "+strconv.Itoa(i))
+	}
+}
+
+func TestIsEmpty(t *testing.T) {
+	list := New(5)
+	assert.True(t, list.IsEmpty())
+	assert.Equal(t, list.Front(), (*SkipListNode)(nil))
+	assert.Equal(t, list.Back(), (*SkipListNode)(nil))
+
+	list.Set("1", []byte("1"))
+	assert.False(t, list.IsEmpty())
+	if assert.NotNil(t, list.Front()) {
+		assert.Equal(t, list.Front().Key(), "1")
+	}
+	if assert.NotNil(t, list.Back()) {
+		assert.Equal(t, list.Back().Key(), "1")
+	}
+
+	list.Remove("1")
+	assert.True(t, list.IsEmpty())
+	assert.Equal(t, list.Front(), (*SkipListNode)(nil))
+	assert.Equal(t, list.Back(), (*SkipListNode)(nil))
+}
+
+func TestIterateNext(t *testing.T) {
+	list := New(5)
+	assert.NotEqual(t, list, nil)
+
+	var temp []string
+	for i := 0; i < 30; i++ {
+		word := randomString(5)
+
+		key := word
+		value := word
+		list.Set(key, []byte(value))
+
+		temp = append(temp, word)
+	}
+
+	sort.Strings(temp)
+
+	node := list.Front()
+	for _, word := range temp {
+		if assert.NotNil(t, node) {
+			assert.Equal(t, node.Key(), word)
+			assert.Equal(t, node.Value(), []byte(word))
+		}
+		node = node.Next()
+	}
+}
+
+func TestIteratePrev(t *testing.T) {
+	list := New(5)
+	assert.NotEqual(t, list, nil)
+
+	var temp []string
+	for i := 0; i < 30; i++ {
+		word := randomString(5)
+
+		key := word
+		value := word
+		list.Set(key, []byte(value))
+
+		temp = append(temp, word)
+	}
+
+	sort.Strings(temp)
+
+	node := list.Back()
+	for i := range temp {
+		word := temp[len(temp)-1-i]
+		if assert.NotNil(t, node) {
+			assert.Equal(t, node.Key(), word)
+			assert.Equal(t, node.Value(), []byte(word))
+		}
+		node = node.Prev()
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	list := New(5)
+	assert.Equal(t, list.Min(), (*SkipListItem)(nil))
+	assert.Equal(t, list.Max(), (*SkipListItem)(nil))
+
+	list.Set("5", []byte("5"))
+	assert.Equal(t, list.Min().Key(), "5")
+	assert.Equal(t, list.Max().Key(), "5")
+
+	for i := 0; i < 10; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+	assert.Equal(t, list.Min().Key(), "0")
+	assert.Equal(t, list.Min().Value(), []byte("0"))
+	assert.Equal(t, list.Max().Key(), "9")
+	assert.Equal(t, list.Max().Value(), []byte("9"))
+}
+
+func TestCeilAndFloor(t *testing.T) {
+	list := New(5)
+	assert.Equal(t, list.Ceil("1"), (*SkipListNode)(nil))
+	assert.Equal(t, list.Floor("1"), (*SkipListNode)(nil))
+
+	for i := 2; i < 10; i += 2 {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	assert.Equal(t, list.Ceil("4").Key(), "4")
+	assert.Equal(t, list.Floor("4").Key(), "4")
+
+	assert.Equal(t, list.Ceil("5").Key(), "6")
+	assert.Equal(t, list.Floor("5").Key(), "4")
+
+	assert.Equal(t, list.Ceil("1").Key(), "2")
+	assert.Equal(t, list.Floor("1"), (*SkipListNode)(nil))
+
+	assert.Equal(t, list.Ceil("9"), (*SkipListNode)(nil))
+	assert.Equal(t, list.Floor("9").Key(), "8")
+}
+
+func TestCeilItemAndFloorItem(t *testing.T) {
+	list := newListWithKeys("2", "4", "6", "8")
+
+	item, exact := list.CeilItem("4")
+	assert.Equal(t, item.Key(), "4")
+	assert.True(t, exact)
+
+	item, exact = list.FloorItem("4")
+	assert.Equal(t, item.Key(), "4")
+	assert.True(t, exact)
+
+	item, exact = list.CeilItem("5")
+	assert.Equal(t, item.Key(), "6")
+	assert.False(t, exact)
+
+	item, exact = list.FloorItem("5")
+	assert.Equal(t, item.Key(), "4")
+	assert.False(t, exact)
+
+	item, exact = list.CeilItem("9")
+	assert.Nil(t, item)
+	assert.False(t, exact)
+
+	item, exact = list.FloorItem("1")
+	assert.Nil(t, item)
+	assert.False(t, exact)
+}
+
+func TestSeekForPrev(t *testing.T) {
+	list := newListWithKeys("b", "d", "f", "h")
+
+	node := list.SeekForPrev("e")
+	var keys []string
+	for ; node != nil; node = node.Prev() {
+		keys = append(keys, node.Key())
+	}
+	assert.Equal(t, keys, []string{"d", "b"})
+
+	node = list.SeekForPrev("f")
+	if assert.NotNil(t, node) {
+		assert.Equal(t, node.Key(), "f")
+	}
+
+	node = list.SeekForPrev("z")
+	if assert.NotNil(t, node) {
+		assert.Equal(t, node.Key(), "h")
+	}
+
+	assert.Equal(t, list.SeekForPrev("a"), (*SkipListNode)(nil))
+}
+
+func TestRankAndSelect(t *testing.T) {
+	list := New(5)
+	assert.NotEqual(t, list, nil)
+
+	_, found := list.Rank("1")
+	assert.False(t, found)
+	assert.Equal(t, list.Select(0), (*SkipListNode)(nil))
+
+	var keys []string
+	for _, i := range rand.Perm(500) {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for i, key := range keys {
+		rank, found := list.Rank(key)
+		if assert.True(t, found) {
+			assert.Equal(t, rank, i)
+		}
+
+		node := list.Select(rank)
+		if assert.NotNil(t, node) {
+			assert.Equal(t, node.Key(), key)
+		}
+	}
+
+	_, found = list.Rank("abc")
+	assert.False(t, found)
+	assert.Equal(t, list.Select(500), (*SkipListNode)(nil))
+
+	for _, i := range rand.Perm(500)[:250] {
+		key := strconv.Itoa(i)
+		list.Remove(key)
+	}
+
+	for i, key := range list.Keys() {
+		rank, found := list.Rank(key)
+		if assert.True(t, found) {
+			assert.Equal(t, rank, i)
+		}
+		assert.Equal(t, list.Select(i).Key(), key)
+	}
+}
+
+func TestSelectOutOfRange(t *testing.T) {
+	list := New(5)
+	for _, n := range []int{-1, 0, 1} {
+		assert.Nil(t, list.Select(n))
+		item, err := list.SelectItem(n)
+		assert.Nil(t, item)
+		assert.Equal(t, err, ErrIndexOutOfRange)
+	}
+
+	list = newListWithKeys("a", "b", "c")
+	for _, n := range []int{-100, -1, 3, 100} {
+		assert.Nil(t, list.Select(n), n)
+		item, err := list.SelectItem(n)
+		assert.Nil(t, item)
+		assert.Equal(t, err, ErrIndexOutOfRange)
+	}
+
+	for n, key := range []string{"a", "b", "c"} {
+		item, err := list.SelectItem(n)
+		assert.Nil(t, err)
+		assert.Equal(t, item.Key(), key)
+	}
+
+	rank, found := list.Rank("z")
+	assert.False(t, found)
+	assert.Equal(t, rank, 0)
+}
+
+func TestCountRange(t *testing.T) {
+	list := New(5)
+	assert.Equal(t, list.CountRange("", ""), 0)
+
+	var keys []string
+	for _, i := range rand.Perm(300) {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+		keys = append(keys, key)
+	}
+
+	bruteForce := func(start, end string) int {
+		count := 0
+		for _, key := range keys {
+			if key >= start && (end == "" || key < end) {
+				count++
+			}
+		}
+		return count
+	}
+
+	for i := 0; i < 200; i++ {
+		start := strconv.Itoa(rand.Intn(350))
+		end := strconv.Itoa(rand.Intn(350))
+		if start > end {
+			assert.Equal(t, list.CountRange(start, end), 0)
+			continue
+		}
+		assert.Equal(t, list.CountRange(start, end), bruteForce(start, end))
+	}
+
+	assert.Equal(t, list.CountRange("", ""), 300)
+	assert.Equal(t, list.CountRange("2", ""), bruteForce("2", ""))
+	assert.Equal(t, list.CountRange("", "2"), bruteForce("", "2"))
+	assert.Equal(t, list.CountRange("5", "5"), 0)
+}
+
+func itemKeys(items []*SkipListItem) []string {
+	keys := make([]string, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, item.Key())
+	}
+	return keys
+}
+
+func TestFirstAndLast(t *testing.T) {
+	list := New(5)
+	assert.Equal(t, len(list.First(3)), 0)
+	assert.Equal(t, len(list.Last(3)), 0)
+
+	for i := 0; i < 10; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	assert.Equal(t, itemKeys(list.First(3)), []string{"0", "1", "2"})
+	assert.Equal(t, itemKeys(list.Last(3)), []string{"7", "8", "9"})
+
+	assert.Equal(t, len(list.First(0)), 0)
+	assert.Equal(t, len(list.Last(-1)), 0)
+
+	assert.Equal(t, itemKeys(list.First(10)), list.Keys())
+	assert.Equal(t, itemKeys(list.Last(10)), list.Keys())
+	assert.Equal(t, itemKeys(list.First(100)), list.Keys())
+	assert.Equal(t, itemKeys(list.Last(100)), list.Keys())
+
+	items := list.First(1)
+	assert.Equal(t, items[0].Value(), []byte("0"))
+	items[0].Value()[0] = 'X'
+	assert.Equal(t, list.Get("0").Value(), []byte("0"))
+}
+
+func TestKeysAndValues(t *testing.T) {
+	list := New(5)
+	assert.NotNil(t, list.Keys())
+	assert.Equal(t, len(list.Keys()), 0)
+	assert.NotNil(t, list.Values())
+	assert.Equal(t, len(list.Values()), 0)
+
+	var temp []string
+	for i := 0; i < 30; i++ {
+		word := randomString(5)
+		list.Set(word, []byte(word))
+		temp = append(temp, word)
+	}
+
+	sort.Strings(temp)
+	assert.Equal(t, list.Keys(), temp)
+
+	values := list.Values()
+	for i, word := range temp {
+		assert.Equal(t, values[i], []byte(word))
+	}
+
+	values[0][0] = '!'
+	assert.Equal(t, list.Get(temp[0]).Value(), []byte(temp[0]))
+}
+
+func TestRange(t *testing.T) {
+	list := New(5)
+	assert.NotEqual(t, list, nil)
+
+	for i := 0; i < 10; i += 2 {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	var keys []string
+	list.Range("2", "7", func(node *SkipListNode) bool {
+		keys = append(keys, node.Key())
+		return true
+	})
+	assert.Equal(t, keys, []string{"2", "4", "6"})
+
+	keys = nil
+	list.Range("1", "5", func(node *SkipListNode) bool {
+		keys = append(keys, node.Key())
+		return true
+	})
+	assert.Equal(t, keys, []string{"2", "4"})
+
+	keys = nil
+	list.Range("0", "9", func(node *SkipListNode) bool {
+		keys = append(keys, node.Key())
+		return len(keys) < 2
+	})
+	assert.Equal(t, keys, []string{"0", "2"})
+
+	keys = nil
+	list.Range("7", "2", func(node *SkipListNode) bool {
+		keys = append(keys, node.Key())
+		return true
+	})
+	assert.Nil(t, keys)
+
+	list.Range("9", "99", func(node *SkipListNode) bool {
+		keys = append(keys, node.Key())
+		return true
+	})
+	assert.Nil(t, keys)
+}
+
+func TestScanPrefix(t *testing.T) {
+	list := newListWithKeys(
+		"user:1:name", "user:10:name", "user:1:age", "user:2:name",
+		"group:1", "user", "users:1", "user:",
+	)
+
+	var keys []string
+	list.ScanPrefix("user:1", func(node *SkipListNode) bool {
+		keys = append(keys, node.Key())
+		return true
+	})
+	assert.Equal(t, keys, []string{"user:10:name", "user:1:age", "user:1:name"})
+
+	keys = nil
+	list.ScanPrefix("user:", func(node *SkipListNode) bool {
+		keys = append(keys, node.Key())
+		return true
+	})
+	assert.Equal(t, keys, []string{"user:", "user:10:name", "user:1:age", "user:1:name", "user:2:name"})
+
+	keys = nil
+	list.ScanPrefix("user:", func(node *SkipListNode) bool {
+		keys = append(keys, node.Key())
+		return len(keys) < 2
+	})
+	assert.Equal(t, keys, []string{"user:", "user:10:name"})
+
+	keys = nil
+	list.ScanPrefix("admin", func(node *SkipListNode) bool {
+		keys = append(keys, node.Key())
+		return true
+	})
+	assert.Nil(t, keys)
+}
+
+func TestDeletePrefix(t *testing.T) {
+	list := newListWithKeys(
+		"user:1:name", "user:10:name", "user:1:age", "user:2:name",
+		"group:1", "group:2", "user", "users:1", "user:",
+	)
+
+	assert.Equal(t, list.DeletePrefix("admin"), 0)
+	assert.Equal(t, list.Length(), 9)
+
+	assert.Equal(t, list.DeletePrefix("user:1"), 3)
+	assert.Equal(t, list.Keys(), []string{"group:1", "group:2", "user", "user:", "user:2:name", "users:1"})
+	checkRanks(t, list)
+
+	assert.Equal(t, list.DeletePrefix("group:"), 2)
+	assert.Equal(t, list.DeletePrefix("user:"), 2)
+	assert.Equal(t, list.Keys(), []string{"user", "users:1"})
+	assert.Equal(t, list.Size(), uint64(2*len("user")+2*len("users:1")))
+	checkRanks(t, list)
+
+	assert.Equal(t, list.DeletePrefix(""), 2)
+	assert.Equal(t, list.Length(), 0)
+	assert.Equal(t, list.Size(), uint64(0))
+	assert.Nil(t, list.Front())
+}
+
+func TestForEach(t *testing.T) {
+	list := New(5)
+	for _, i := range rand.Perm(10) {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	var keys []string
+	list.ForEach(func(key string, value []byte) bool {
+		assert.Equal(t, value, []byte(key))
+		keys = append(keys, key)
+		value[0] = 'X'
+		return true
+	})
+	assert.Equal(t, keys, list.Keys())
+	assert.Equal(t, list.Get("3").Value(), []byte("3"))
+
+	keys = nil
+	list.ForEach(func(key string, value []byte) bool {
+		keys = append(keys, key)
+		return len(keys) < 3
+	})
+	assert.Equal(t, keys, []string{"0", "1", "2"})
+}
+
+func TestRangeContext(t *testing.T) {
+	list := New(10)
+	for i := 0; i < 10000; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	count := 0
+	err := list.RangeContext(context.Background(), "", "~", func(node *SkipListNode) bool {
+		count++
+		return true
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, count, 10000)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	count = 0
+	err = list.RangeContext(ctx, "", "~", func(node *SkipListNode) bool {
+		count++
+		if count == 100 {
+			cancel()
+		}
+		return true
+	})
+	assert.Equal(t, err, context.Canceled)
+	assert.Equal(t, count, 1024)
+
+	count = 0
+	err = list.RangeContext(ctx, "", "~", func(node *SkipListNode) bool {
+		count++
+		return true
+	})
+	assert.Equal(t, err, context.Canceled)
+	assert.Equal(t, count, 0)
+}
+
+func TestForEachContext(t *testing.T) {
+	list := New(10)
+	for i := 0; i < 5000; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	count := 0
+	err := list.ForEachContext(ctx, func(key string, value []byte) bool {
+		count++
+		return count < 10
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, count, 10)
+
+	count = 0
+	err = list.ForEachContext(ctx, func(key string, value []byte) bool {
+		count++
+		if count == 2000 {
+			cancel()
+		}
+		return true
+	})
+	assert.Equal(t, err, context.Canceled)
+	assert.Equal(t, count, 2*1024)
+}
+
+func numericCompare(a, b string) int {
+	left, _ := strconv.Atoi(a)
+	right, _ := strconv.Atoi(b)
+	return left - right
+}
+
+func TestComparator(t *testing.T) {
+	list := NewWithComparator(5, numericCompare)
+	assert.NotEqual(t, list, nil)
+
+	for _, i := range rand.Perm(20) {
+		key := strconv.Itoa(i)
+		value := strconv.Itoa(i)
+		list.Set(key, []byte(value))
+	}
+
+	node := list.Front()
+	for i := 0; i < 20; i++ {
+		if assert.NotNil(t, node) {
+			assert.Equal(t, node.Key(), strconv.Itoa(i))
+		}
+		node = node.Next()
+	}
+
+	node = list.Back()
+	for i := 19; i >= 0; i-- {
+		if assert.NotNil(t, node) {
+			assert.Equal(t, node.Key(), strconv.Itoa(i))
+		}
+		node = node.Prev()
+	}
+
+	item := list.Get("10")
+	if assert.NotNil(t, item) {
+		assert.Equal(t, item.Value(), []byte("10"))
+	}
+}
+
+func TestDefaultComparator(t *testing.T) {
+	list := New(5)
+	list.Set("9", []byte("9"))
+	list.Set("10", []byte("10"))
+
+	assert.Equal(t, list.Front().Key(), "10")
+	assert.Equal(t, list.Back().Key(), "9")
+}
+
+func TestConcurrency(t *testing.T) {
+	list := New(10)
+
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+
+	go func() {
+		for i := 0; i < 100000; i++ {
+			key := strconv.Itoa(i)
+			value := strconv.Itoa(i)
+			list.Set(key, []byte(value))
+		}
+		wg.Done()
+	}()
+
+	go func() {
+		for i := 0; i < 100000; i++ {
+			key := strconv.Itoa(i)
+			list.Get(key)
+		}
+		wg.Done()
+	}()
+
+	wg.Wait()
+	assert.Equal(t, list.Length(), 100000)
+}
+
+func TestConcurrentSet(t *testing.T) {
+	list := New(10)
+
+	const workers = 16
+	const keys = 1000
+
+	wg := &sync.WaitGroup{}
+	wg.Add(workers)
+
+	for w := 0; w < workers; w++ {
+		go func(offset int) {
+			for i := 0; i < keys; i++ {
+				key := strconv.Itoa((i + offset) % keys)
+				list.Set(key, []byte(key))
+			}
+			wg.Done()
+		}(w * 37)
+	}
+
+	wg.Wait()
+	assert.Equal(t, list.Length(), keys)
+
+	count := 0
+	prev := ""
+	for node := list.Front(); node != nil; node = node.Next() {
+		if count > 0 {
+			assert.Less(t, prev, node.Key())
+		}
+		assert.Equal(t, node.Value(), []byte(node.Key()))
+		prev = node.Key()
+		count++
+	}
+	assert.Equal(t, count, keys)
+}
+
+func TestConcurrentSetSameKeys(t *testing.T) {
+	list := New(10)
+
+	const workers = 32
+	keys := []string{"a", "b", "c", "d", "e"}
+
+	wg := &sync.WaitGroup{}
+	wg.Add(workers)
+
+	for w := 0; w < workers; w++ {
+		go func(worker int) {
+			for i := 0; i < 100; i++ {
+				for _, key := range keys {
+					list.Set(key, []byte(strconv.Itoa(worker)))
+				}
+			}
+			wg.Done()
+		}(w)
+	}
+
+	wg.Wait()
+	assert.Equal(t, list.Length(), len(keys))
+
+	occurrences := map[string]int{}
+	for node := list.Front(); node != nil; node = node.Next() {
+		occurrences[node.Key()]++
+	}
+
+	for _, key := range keys {
+		assert.Equal(t, occurrences[key], 1)
+		assert.NotNil(t, list.Get(key))
+	}
+}
+
+func TestConcurrentReadersAndWriters(t *testing.T) {
+	list := New(10)
+	for i := 0; i < 1000; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	const readers = 8
+
+	wg := &sync.WaitGroup{}
+	wg.Add(readers + 1)
+
+	go func() {
+		for i := 0; i < 5000; i++ {
+			key := strconv.Itoa(rand.Intn(2000))
+			if i%2 == 0 {
+				list.Set(key, []byte(key))
+			} else {
+				list.Remove(key)
+			}
+		}
+		wg.Done()
+	}()
+
+	for r := 0; r < readers; r++ {
+		go func() {
+			for i := 0; i < 5000; i++ {
+				key := strconv.Itoa(rand.Intn(2000))
+				if item := list.Get(key); item != nil {
+					assert.Equal(t, item.Key(), key)
+				}
+				list.Contains(key)
+			}
+			wg.Done()
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(t, len(list.Keys()), list.Length())
+}
+
+func TestConcurrentLengthAndSize(t *testing.T) {
+	list := New(10)
+
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 2000; i++ {
+			key := strconv.Itoa(i % 100)
+			if i%3 == 0 {
+				list.Remove(key)
+			} else {
+				list.Set(key, []byte(key))
+			}
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 2000; i++ {
+			assert.LessOrEqual(t, list.Length(), 100)
+			assert.LessOrEqual(t, list.Size(), uint64(400))
+		}
+	}()
+
+	wg.Wait()
+	assert.Equal(t, list.Length(), len(list.Keys()))
+	checkRanks(t, list)
+}
+
+var benchList *SkipList
+
+func BenchmarkSet(b *testing.B) {
+	b.ReportAllocs()
+
+	benchList = New(15)
+
+	for i := 0; i < b.N; i++ {
+		key := strconv.Itoa(i)
+		value := strconv.Itoa(i)
+		benchList.Set(key, []byte(value))
+	}
+
+	b.SetBytes(int64(b.N))
+}
+
+func BenchmarkGet(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		key := strconv.Itoa(i)
+		benchList.Get(key)
 	}
 
 	b.SetBytes(int64(b.N))
 }
+
+func BenchmarkGetParallel(b *testing.B) {
+	b.ReportAllocs()
+
+	list := New(15)
+	for i := 0; i < 100000; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%20 == 0 {
+				key := strconv.Itoa(i % 100000)
+				list.Set(key, []byte(key))
+			} else {
+				list.Get(strconv.Itoa(i % 100000))
+			}
+			i++
+		}
+	})
+}
+
+func batchItems(count int) []SkipListItem {
+	items := make([]SkipListItem, 0, count)
+	for _, i := range rand.Perm(count) {
+		key := strconv.Itoa(i)
+		items = append(items, NewItem(key, []byte(key)))
+	}
+	return items
+}
+
+func sortedKeys(count int) []string {
+	keys := make([]string, count)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("%08d", i)
+	}
+	return keys
+}
+
+func BenchmarkSetSorted10k(b *testing.B) {
+	b.ReportAllocs()
+	keys := sortedKeys(10000)
+
+	for i := 0; i < b.N; i++ {
+		list := New(15)
+		for _, key := range keys {
+			list.Set(key, nil)
+		}
+	}
+}
+
+func BenchmarkAppendSorted10k(b *testing.B) {
+	b.ReportAllocs()
+	keys := sortedKeys(10000)
+
+	for i := 0; i < b.N; i++ {
+		list := New(15)
+		for _, key := range keys {
+			list.AppendSorted(key, nil)
+		}
+	}
+}
+
+func BenchmarkGetLoop100(b *testing.B) {
+	b.ReportAllocs()
+	list, keys := benchmarkLookupList()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, key := range keys {
+			list.Load(key)
+		}
+	}
+}
+
+func BenchmarkGetMulti100(b *testing.B) {
+	b.ReportAllocs()
+	list, keys := benchmarkLookupList()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		list.GetMulti(keys)
+	}
+}
+
+func benchmarkLookupList() (*SkipList, []string) {
+	list := New(15)
+	for i := 0; i < 100000; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	keys := make([]string, 100)
+	for i := range keys {
+		keys[i] = strconv.Itoa(rand.Intn(100000))
+	}
+	return list, keys
+}
+
+func BenchmarkSetLoop10k(b *testing.B) {
+	b.ReportAllocs()
+	items := batchItems(10000)
+
+	for i := 0; i < b.N; i++ {
+		list := New(15)
+		for _, item := range items {
+			list.Set(item.Key(), item.Value())
+		}
+	}
+}
+
+func BenchmarkSetBatch10k(b *testing.B) {
+	b.ReportAllocs()
+	items := batchItems(10000)
+
+	for i := 0; i < b.N; i++ {
+		list := New(15)
+		list.SetBatch(items)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+// Snapshot is a read-only view of a list as of the time it was taken.
+// Later writes to the list are not visible through it.
+type Snapshot struct {
+	list *SkipList
+}
+
+func (list *SkipList) Snapshot() *Snapshot {
+	return &Snapshot{list: list.Clone()}
+}
+
+func (snapshot *Snapshot) Length() int {
+	return snapshot.list.Length()
+}
+
+func (snapshot *Snapshot) Size() uint64 {
+	return snapshot.list.Size()
+}
+
+func (snapshot *Snapshot) Get(key string) *SkipListItem {
+	return snapshot.list.Get(key)
+}
+
+func (snapshot *Snapshot) Contains(key string) bool {
+	return snapshot.list.Contains(key)
+}
+
+func (snapshot *Snapshot) Keys() []string {
+	return snapshot.list.Keys()
+}
+
+func (snapshot *Snapshot) Values() [][]byte {
+	return snapshot.list.Values()
+}
+
+func (snapshot *Snapshot) ForEach(fn func(key string, value []byte) bool) {
+	snapshot.list.ForEach(fn)
+}
+
+func (snapshot *Snapshot) Range(start, end string, fn func(node *SkipListNode) bool) {
+	snapshot.list.Range(start, end, fn)
+}
+
+func (snapshot *Snapshot) Iterator() *Iterator {
+	return snapshot.list.Iterator()
+}
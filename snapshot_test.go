@@ -0,0 +1,78 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+	list := New(5)
+	for i := 0; i < 100; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+
+	snapshot := list.Snapshot()
+	keys := list.Keys()
+	values := list.Values()
+	size := list.Size()
+
+	list.Set("1", []byte("changed"))
+	list.Set("100", []byte("100"))
+	list.Remove("2")
+	list.Clear()
+
+	assert.Equal(t, snapshot.Length(), 100)
+	assert.Equal(t, snapshot.Size(), size)
+	assert.Equal(t, snapshot.Keys(), keys)
+	assert.Equal(t, snapshot.Values(), values)
+	assert.Equal(t, snapshot.Get("1").Value(), []byte("1"))
+	assert.True(t, snapshot.Contains("2"))
+	assert.False(t, snapshot.Contains("100"))
+
+	var iterated []string
+	for it := snapshot.Iterator(); it.Valid(); it.Next() {
+		iterated = append(iterated, it.Key())
+	}
+	assert.Equal(t, iterated, keys)
+
+	count := 0
+	snapshot.ForEach(func(key string, value []byte) bool {
+		count++
+		return true
+	})
+	assert.Equal(t, count, 100)
+
+	var ranged []string
+	snapshot.Range("1", "11", func(node *SkipListNode) bool {
+		ranged = append(ranged, node.Key())
+		return true
+	})
+	assert.Equal(t, ranged, []string{"1", "10"})
+}
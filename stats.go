@@ -0,0 +1,45 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import "github.com/ISSuh/skiplist/generic"
+
+// Stats counts calls since the list was created, as described on
+// generic.Stats. SetBytes, SetWithTTL and decoding count as sets;
+// CompareAndSwap and ValueAppend change existing values and are not counted.
+type Stats = generic.Stats
+
+func (list *SkipList) Stats() Stats {
+	return list.core.Stats()
+}
+
+// AvgSearchLength returns the average number of forward hops a lookup of a
+// stored key takes. Up to 1024 keys spread evenly over the list are looked
+// up, so the result is exact for small lists. A value far above
+// log2(Length()) suggests raising the max level. It returns 0 for an empty
+// list.
+func (list *SkipList) AvgSearchLength() float64 {
+	return list.core.AvgSearchLength()
+}
@@ -0,0 +1,137 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	list := New(5)
+	assert.Equal(t, list.Stats(), Stats{})
+
+	list.Set("a", []byte("a"))
+	list.Set("b", []byte("b"))
+	list.Set("a", []byte("aa"))
+	list.SetAndGet("c", []byte("c"))
+	list.SetWithTTL("d", []byte("d"), time.Hour)
+
+	list.Get("a")
+	list.Get("x")
+	list.Load("b")
+	list.Load("y")
+	list.Get("z")
+
+	list.Remove("a")
+	list.Remove("a")
+
+	assert.Equal(t, list.Stats(), Stats{
+		Sets:    5,
+		Gets:    5,
+		Hits:    2,
+		Misses:  3,
+		Removes: 2,
+	})
+}
+
+func TestAvgSearchLength(t *testing.T) {
+	assert.Equal(t, New(5).AvgSearchLength(), 0.0)
+
+	averages := []float64{}
+	for _, maxLevel := range []int{1, 2, 4, 12} {
+		list := NewWithRand(maxLevel, rand.NewSource(42))
+		for i := 0; i < 1000; i++ {
+			key := strconv.Itoa(i)
+			list.Set(key, []byte(key))
+		}
+		averages = append(averages, list.AvgSearchLength())
+	}
+
+	// a single level list walks past every smaller key
+	assert.Equal(t, averages[0], 499.5)
+	for i := 1; i < len(averages); i++ {
+		assert.Less(t, averages[i], averages[i-1])
+	}
+	assert.Less(t, averages[3], 30.0)
+}
+
+func TestAvgSearchLengthSampled(t *testing.T) {
+	// AvgSearchLength looks up at most 1024 keys
+	const searchSamples = 1024
+
+	list := New(1)
+	for i := 0; i < 3*searchSamples; i++ {
+		list.Set(fmt.Sprintf("%06d", i), nil)
+	}
+
+	// every third key is looked up, and the key at position p takes p hops
+	assert.Equal(t, list.AvgSearchLength(), float64(3*(searchSamples-1))/2)
+}
+
+func TestStatsWritePaths(t *testing.T) {
+	list := New(5)
+
+	list.SetBatch([]SkipListItem{NewItem("a", nil), NewItem("b", nil), NewItem("a", nil)})
+	assert.Equal(t, list.Stats().Sets, uint64(3))
+
+	list.PutAll(map[string][]byte{"c": nil, "d": nil})
+	assert.Equal(t, list.Stats().Sets, uint64(5))
+
+	list.Merge(newListWithKeys("e", "f"))
+	assert.Equal(t, list.Stats().Sets, uint64(7))
+
+	list.Apply([]Op{{Kind: OpSet, Key: "g"}, {Kind: OpRemove, Key: "g"}})
+	assert.Equal(t, list.Stats().Sets, uint64(8))
+
+	list.GetOrSet("a", nil)
+	list.SetIfAbsent("a", nil)
+	assert.Equal(t, list.Stats().Sets, uint64(8))
+
+	list.GetOrSet("h", nil)
+	list.SetIfAbsent("i", nil)
+	assert.Equal(t, list.Stats().Sets, uint64(10))
+
+	list.AppendSorted("j", nil)
+	list.SetBytes([]byte("k"), nil)
+	list.SetWithLevel("l", nil, 1)
+	list.TrySet("m", nil, time.Second)
+	list.SetUnlocked("n", nil)
+	list.WithWriteLock(func(view WriteView) {
+		view.Set("o", nil)
+	})
+	assert.Equal(t, list.Stats().Sets, uint64(16))
+
+	list.ReplaceValue("a", []byte("1"))
+	list.CompareAndSwap("a", []byte("1"), []byte("2"))
+	list.ValueAppend("a", []byte("3"))
+	list.Swap("a", "b")
+	assert.Equal(t, list.Stats().Sets, uint64(16))
+}
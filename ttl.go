@@ -0,0 +1,51 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import "time"
+
+func (list *SkipList) SetWithTTL(key string, value []byte, ttl time.Duration) {
+	list.core.SetWithTTL(key, value, ttl)
+}
+
+// GC removes every expired entry and returns how many were removed.
+func (list *SkipList) GC() int {
+	return list.core.GC()
+}
+
+// EnableExpiry starts a goroutine that calls GC every interval, so expired
+// entries are removed even if they are never read again. Removed entries are
+// reported to the OnRemove hook. Calling it again restarts the sweeper with
+// the new interval; a non-positive interval only stops it. The sweeper keeps
+// the list alive, so call StopExpiry once the list is no longer needed.
+func (list *SkipList) EnableExpiry(interval time.Duration) {
+	list.core.EnableExpiry(interval)
+}
+
+// StopExpiry stops the sweeper started by EnableExpiry and waits for it to
+// exit.
+func (list *SkipList) StopExpiry() {
+	list.core.StopExpiry()
+}
@@ -0,0 +1,152 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testClock struct {
+	current time.Time
+}
+
+func (clock *testClock) Now() time.Time {
+	return clock.current
+}
+
+func (clock *testClock) Advance(duration time.Duration) {
+	clock.current = clock.current.Add(duration)
+}
+
+func newListWithClock(maxLevel int) (*SkipList, *testClock) {
+	clock := &testClock{current: time.Unix(0, 0)}
+	list, _ := NewWithOptions(Options{MaxLevel: maxLevel, Clock: clock.Now})
+	return list, clock
+}
+
+func TestSetWithTTL(t *testing.T) {
+	list, clock := newListWithClock(5)
+
+	list.SetWithTTL("ttl", []byte("value"), time.Second)
+	list.Set("permanent", []byte("value"))
+	assert.Equal(t, list.Length(), 2)
+	assert.Equal(t, list.Size(), uint64(22))
+
+	clock.Advance(500 * time.Millisecond)
+	assert.True(t, list.Contains("ttl"))
+	assert.Equal(t, list.Get("ttl").Value(), []byte("value"))
+
+	clock.Advance(500 * time.Millisecond)
+	assert.False(t, list.Contains("ttl"))
+	assert.Equal(t, list.Get("ttl"), (*SkipListItem)(nil))
+	assert.Equal(t, list.Length(), 1)
+	assert.Equal(t, list.Size(), uint64(14))
+
+	clock.Advance(time.Hour)
+	assert.True(t, list.Contains("permanent"))
+}
+
+func TestSetClearsTTL(t *testing.T) {
+	list, clock := newListWithClock(5)
+
+	list.SetWithTTL("key", []byte("1"), time.Second)
+	list.Set("key", []byte("2"))
+
+	clock.Advance(time.Hour)
+	assert.Equal(t, list.Get("key").Value(), []byte("2"))
+
+	list.SetWithTTL("key", []byte("3"), time.Second)
+	clock.Advance(time.Second)
+
+	old, existed := list.SetAndGet("key", []byte("4"))
+	assert.False(t, existed)
+	assert.Nil(t, old)
+
+	list.SetWithTTL("key", []byte("5"), time.Second)
+	clock.Advance(time.Second)
+
+	actual, loaded := list.GetOrSet("key", []byte("6"))
+	assert.False(t, loaded)
+	assert.Equal(t, actual, []byte("6"))
+	assert.Equal(t, list.Length(), 1)
+}
+
+func TestGC(t *testing.T) {
+	list, clock := newListWithClock(5)
+
+	list.SetWithTTL("a", []byte("a"), time.Second)
+	list.SetWithTTL("b", []byte("b"), 2*time.Second)
+	list.SetWithTTL("c", []byte("c"), time.Second)
+	list.Set("d", []byte("d"))
+
+	assert.Equal(t, list.GC(), 0)
+
+	clock.Advance(time.Second)
+	assert.Equal(t, list.GC(), 2)
+	assert.Equal(t, list.Keys(), []string{"b", "d"})
+	assert.Equal(t, list.Length(), 2)
+	assert.Equal(t, list.Size(), uint64(4))
+
+	clock.Advance(time.Second)
+	assert.Equal(t, list.GC(), 1)
+	assert.Equal(t, list.Keys(), []string{"d"})
+}
+
+func TestEnableExpiry(t *testing.T) {
+	list := New(5)
+
+	var removed atomic.Int32
+	list.OnRemove(func(key string, value []byte) {
+		removed.Add(1)
+	})
+
+	list.SetWithTTL("a", []byte("a"), 10*time.Millisecond)
+	list.SetWithTTL("b", []byte("b"), 10*time.Millisecond)
+	list.Set("c", []byte("c"))
+
+	list.EnableExpiry(5 * time.Millisecond)
+	// the hook runs after the lock is released, so wait for it as well
+	assert.Eventually(t, func() bool {
+		return len(list.Keys()) == 1 && removed.Load() == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, list.Keys(), []string{"c"})
+
+	// restarting replaces the running sweeper
+	list.EnableExpiry(time.Millisecond)
+	list.StopExpiry()
+
+	list.SetWithTTL("d", []byte("d"), time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, list.Keys(), []string{"c", "d"})
+
+	list.StopExpiry()
+	list.EnableExpiry(0)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, list.Keys(), []string{"c", "d"})
+}
@@ -0,0 +1,39 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+// SetUnlocked works like Set without taking the list's lock. It is meant for
+// single goroutine phases such as a bulk load, and the caller must make sure
+// no other goroutine uses the list until the call returns. Mixing it with
+// concurrent calls corrupts the list.
+func (list *SkipList) SetUnlocked(key string, value []byte) {
+	list.core.SetUnlocked(key, value)
+}
+
+// GetUnlocked works like Get without taking the list's lock, under the same
+// contract as SetUnlocked.
+func (list *SkipList) GetUnlocked(key string) *SkipListItem {
+	return list.core.GetUnlocked(key)
+}
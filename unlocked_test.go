@@ -0,0 +1,87 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnlocked(t *testing.T) {
+	list := New(10)
+	for i := 0; i < 1000; i++ {
+		key := strconv.Itoa(i)
+		list.SetUnlocked(key, []byte(key))
+	}
+
+	assert.Equal(t, list.Length(), 1000)
+	assert.Equal(t, list.GetUnlocked("500").Value(), []byte("500"))
+	assert.Equal(t, list.Get("999").Value(), []byte("999"))
+	assert.Nil(t, list.GetUnlocked("1000"))
+	checkRanks(t, list)
+
+	list.SetUnlocked("500", []byte("changed"))
+	assert.Equal(t, list.Length(), 1000)
+	assert.Equal(t, list.GetUnlocked("500").Value(), []byte("changed"))
+
+	stats := list.Stats()
+	assert.Equal(t, stats.Sets, uint64(1001))
+	assert.Equal(t, stats.Hits, uint64(3))
+	assert.Equal(t, stats.Misses, uint64(1))
+}
+
+func TestGetUnlockedExpired(t *testing.T) {
+	list, clock := newListWithClock(5)
+	list.SetWithTTL("a", []byte("a"), time.Second)
+	list.SetUnlocked("b", []byte("b"))
+
+	clock.Advance(2 * time.Second)
+	assert.Nil(t, list.GetUnlocked("a"))
+	assert.Equal(t, list.Length(), 1)
+	assert.Equal(t, list.GetUnlocked("b").Value(), []byte("b"))
+}
+
+func BenchmarkSetLocked(b *testing.B) {
+	b.ReportAllocs()
+	list := New(15)
+
+	for i := 0; i < b.N; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+}
+
+func BenchmarkSetUnlocked(b *testing.B) {
+	b.ReportAllocs()
+	list := New(15)
+
+	for i := 0; i < b.N; i++ {
+		key := strconv.Itoa(i)
+		list.SetUnlocked(key, []byte(key))
+	}
+}
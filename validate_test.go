@@ -0,0 +1,59 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	list := New(10)
+	assert.Nil(t, list.Validate())
+
+	for i := 0; i < 1000; i++ {
+		key := strconv.Itoa(i)
+		list.Set(key, []byte(key))
+	}
+	assert.Nil(t, list.Validate())
+
+	for i := 0; i < 1000; i += 3 {
+		list.Remove(strconv.Itoa(i))
+	}
+	list.DeleteRange("5", "6")
+	list.PopMin()
+	assert.Nil(t, list.Validate())
+
+	list.Compact()
+	assert.Nil(t, list.Validate())
+
+	multiset, err := NewWithOptions(Options{MaxLevel: 5, Multiset: true})
+	assert.Nil(t, err)
+	multiset.Set("a", []byte("1"))
+	multiset.Set("a", []byte("2"))
+	assert.Nil(t, multiset.Validate())
+}
@@ -0,0 +1,48 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import "github.com/ISSuh/skiplist/generic"
+
+// ReadView gives access to the list while WithReadLock holds its read lock.
+// It must not be used after the callback returns.
+type ReadView = generic.ReadView[string, []byte]
+
+// WriteView gives access to the list while WithWriteLock holds its write
+// lock. It must not be used after the callback returns.
+type WriteView = generic.WriteView[string, []byte]
+
+// WithReadLock runs fn under the read lock so that several reads see the same
+// state. fn must use view instead of the list's own methods, which would
+// deadlock against the held lock.
+func (list *SkipList) WithReadLock(fn func(view ReadView)) {
+	list.core.WithReadLock(fn)
+}
+
+// WithWriteLock runs fn under the write lock so that a sequence of reads and
+// writes is applied atomically. The same rules as WithReadLock apply.
+func (list *SkipList) WithWriteLock(fn func(view WriteView)) {
+	list.core.WithWriteLock(fn)
+}
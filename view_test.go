@@ -0,0 +1,103 @@
+/*
+MIT License
+
+Copyright (c) 2023 ISSuh
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package skiplist
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithReadLock(t *testing.T) {
+	list := New(10)
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("%06d", i)
+		list.Set(key, []byte(key))
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// slide a window of 100 consecutive keys forward
+		for i := 100; i < 2100; i++ {
+			list.WithWriteLock(func(view WriteView) {
+				key := fmt.Sprintf("%06d", i)
+				view.Set(key, []byte(key))
+				assert.True(t, view.Remove(view.Min().Key()))
+			})
+		}
+	}()
+
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				list.WithReadLock(func(view ReadView) {
+					low, _ := strconv.Atoi(view.Min().Key())
+					high, _ := strconv.Atoi(view.Max().Key())
+					assert.Equal(t, high-low+1, view.Length())
+					assert.True(t, view.Contains(view.Min().Key()))
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	list.WithReadLock(func(view ReadView) {
+		assert.Equal(t, view.Min().Key(), "002000")
+		assert.Equal(t, view.Max().Key(), "002099")
+		assert.Equal(t, view.Get("002050").Value(), []byte("002050"))
+		assert.Nil(t, view.Get("000001"))
+		assert.Equal(t, view.Size(), uint64(100*12))
+	})
+}
+
+func TestWithWriteLock(t *testing.T) {
+	list := New(5)
+
+	removed := 0
+	list.OnRemove(func(key string, value []byte) {
+		removed++
+	})
+
+	list.WithWriteLock(func(view WriteView) {
+		assert.Nil(t, view.Min())
+		assert.Nil(t, view.Max())
+
+		view.Set("a", []byte("a"))
+		view.Set("b", []byte("b"))
+		assert.False(t, view.Remove("c"))
+		assert.True(t, view.Remove("a"))
+		assert.Equal(t, removed, 0)
+	})
+
+	assert.Equal(t, removed, 1)
+	assert.Equal(t, list.Keys(), []string{"b"})
+}